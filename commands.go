@@ -0,0 +1,765 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PersoSirEduard/kode/kode"
+)
+
+// ! command : A command of the command line (e.g. "kode fmt").
+// -------------------------
+// ! name : The name of the command.
+// -------------------------
+// ! description : A short description of the command.
+// -------------------------
+// ! run : Run the command with the arguments after its name and return the exit status.
+type command struct {
+	name        string
+	description string
+	run         func(args []string, opts *options) int
+}
+
+/**
+ * Get the commands of the command line.
+ * @return []command - The commands, in the order of the help.
+ */
+func commands() []command {
+	return []command{
+		{"run", "Run a Kode program", runCommand},
+		{"repl", "Start an interactive session", replCommand},
+		{"check", "Parse and type-check Kode files without running them", checkCommand},
+		{"fmt", "Format Kode files", fmtCommand},
+		{"test", "Run the test functions of the *_test.kd files", testCommand},
+		{"doc", "Show the documentation of the functions of Kode files", docCommand},
+		{"version", "Show the current version of Kode", versionCommand},
+		{"help", "Show the help of a command", helpCommand},
+	}
+}
+
+/**
+ * Find a command by name.
+ * @param name : string - The name of the command.
+ * @return command - The command.
+ * @return bool - True if the command exists.
+ */
+func findCommand(name string) (command, bool) {
+	for _, command := range commands() {
+		if command.name == name {
+			return command, true
+		}
+	}
+	return command{}, false
+}
+
+// ! options : The options shared by the commands.
+// -------------------------
+// ! debug : The initial value of _DEBUG (--debug).
+// -------------------------
+// ! maxRecursion : The initial value of _MAX_RECURSION (--max-recursion).
+// -------------------------
+// ! seed : The seed of the random number generator (-seed).
+// -------------------------
+// ! sandbox : True to deny the access to the file system and the environment (-sandbox).
+// -------------------------
+// ! maxTraceFrames : The maximum number of frames in the call stack of an error (-max-trace-frames).
+// -------------------------
+// ! traceArguments : True to show the arguments of the calls in the call stack of an error (-trace-args).
+// -------------------------
+// ! color : When to color the errors: "auto" (on terminals), "always" or "never" (--color).
+// -------------------------
+// ! errorFormat : How to print the errors: "text", or "json" for one JSON diagnostic per line (--error-format).
+// -------------------------
+// ! language : The language of the errors (--lang), from the locale by default (see kode.LANGUAGES).
+type options struct {
+	debug          bool
+	maxRecursion   int64
+	seed           seedFlag
+	sandbox        bool
+	maxTraceFrames int
+	traceArguments bool
+	color          string
+	errorFormat    string
+	language       string
+}
+
+/**
+ * Create the default options.
+ * @return *options - The options of a new interpreter.
+ */
+func newOptions() *options {
+	interpreter := kode.NewInterpreter()
+	return &options{
+		debug:          interpreter.Debug,
+		maxRecursion:   interpreter.MaxRecursion,
+		maxTraceFrames: interpreter.MaxTraceFrames,
+		traceArguments: interpreter.TraceArguments,
+		color:          "auto",
+		errorFormat:    "text",
+		language:       localeLanguage(),
+	}
+}
+
+/**
+ * Get the language of the locale of the user, from LC_ALL, LC_MESSAGES or LANG.
+ * @return string - The language if it is supported (see kode.LANGUAGES), or else English.
+ */
+func localeLanguage() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			if language := kode.FindLanguage(locale); language != "" {
+				return language
+			}
+			return "en"
+		}
+	}
+	return "en"
+}
+
+/**
+ * Create an interpreter configured by the options.
+ * @return *kode.Interpreter - The new interpreter.
+ */
+func (opts *options) newInterpreter() *kode.Interpreter {
+	interpreter := kode.NewInterpreter()
+	interpreter.Debug = (*opts).debug
+	interpreter.MaxRecursion = (*opts).maxRecursion
+	interpreter.MaxTraceFrames = (*opts).maxTraceFrames
+	interpreter.TraceArguments = (*opts).traceArguments
+	interpreter.Language = (*opts).language
+
+	// Only seed the generator if the flag is provided
+	if (*opts).seed.isSet {
+		interpreter.Seed((*opts).seed.value)
+	}
+	if (*opts).sandbox {
+		interpreter.Permissions = kode.Permissions{}
+	}
+	return interpreter
+}
+
+/**
+ * Check if the errors are colored. With "auto", they are colored if the standard error is a terminal,
+ * unless NO_COLOR is set or the terminal is "dumb".
+ * @return bool - True to color the errors.
+ */
+func (opts *options) useColor() bool {
+	switch (*opts).color {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	return !isPiped(os.Stderr) && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+}
+
+/**
+ * Print an error on the standard error in the language of the options. The errors of Kode code are rendered with
+ * their code and source line, or printed as a JSON diagnostic (see kode.Diagnostic) with --error-format=json.
+ * @param err : error - The error.
+ */
+func (opts *options) printError(err error) {
+
+	// Every syntax error is reported
+	var syntaxErrors *kode.SyntaxErrors
+	if errors.As(err, &syntaxErrors) {
+		for _, syntaxError := range (*syntaxErrors).Errors {
+			opts.printError(syntaxError)
+		}
+		return
+	}
+
+	var errorStack *kode.ErrorStack
+	isErrorStack := errors.As(err, &errorStack)
+	if isErrorStack {
+		errorStack.Localize((*opts).language)
+	}
+
+	if (*opts).errorFormat == "json" {
+		encoded, _ := json.Marshal(kode.ErrorDiagnostic(err))
+		fmt.Fprintln(os.Stderr, string(encoded))
+		return
+	}
+
+	if isErrorStack {
+		fmt.Fprintln(os.Stderr, errorStack.Render(opts.useColor()))
+		return
+	}
+	fmt.Fprintln(os.Stderr, err.Error())
+}
+
+// ! seedFlag : A seed only used if the flag is provided.
+type seedFlag struct {
+	value int64
+	isSet bool
+}
+
+func (seed *seedFlag) String() string {
+	return strconv.FormatInt((*seed).value, 10)
+}
+
+func (seed *seedFlag) Set(value string) error {
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return errors.New("the seed must be an integer")
+	}
+	(*seed).value, (*seed).isSet = number, true
+	return nil
+}
+
+/**
+ * Create the flag set of a command with the global flags (--debug, --max-recursion, --color, --error-format and --lang).
+ * The global flags keep the values given before the command (e.g. kode --debug run main.kd).
+ * @param name : string - The name of the command.
+ * @param usage : string - The arguments of the command.
+ * @param description : string - The description of the command.
+ * @param opts : *options - The options set by the global flags.
+ * @return *flag.FlagSet - The flag set.
+ */
+func newFlagSet(name string, usage string, description string, opts *options) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		program := "kode"
+		if name != "kode" {
+			program += " " + name
+		}
+		fmt.Fprintf(flags.Output(), "Usage: %s %s\n\n%s\n\nFlags:\n", program, usage, description)
+		flags.PrintDefaults()
+	}
+
+	flags.BoolVar(&(*opts).debug, "debug", (*opts).debug, "Print debug information (sets _DEBUG to true).")
+	flags.Int64Var(&(*opts).maxRecursion, "max-recursion", (*opts).maxRecursion, "Maximum depth of function calls (sets _MAX_RECURSION).")
+	flags.Func("color", "Color the errors: auto (on terminals), always or never. (default \"auto\")", func(value string) error {
+		if value != "auto" && value != "always" && value != "never" {
+			return errors.New("expected auto, always or never")
+		}
+		(*opts).color = value
+		return nil
+	})
+	flags.Func("error-format", "Format of the errors: text, or json for one diagnostic per line. (default \"text\")", func(value string) error {
+		if value != "text" && value != "json" {
+			return errors.New("expected text or json")
+		}
+		(*opts).errorFormat = value
+		return nil
+	})
+	flags.Func("lang", "Language of the errors: "+strings.Join(kode.LANGUAGES, " or ")+". (default from LANG)", func(value string) error {
+		if kode.FindLanguage(value) != value {
+			return errors.New("expected " + strings.Join(kode.LANGUAGES, " or "))
+		}
+		(*opts).language = value
+		return nil
+	})
+	return flags
+}
+
+/**
+ * Add the flags configuring the interpreter (-seed, -sandbox, -max-trace-frames and -trace-args) to a flag set.
+ * @param flags : *flag.FlagSet - The flag set.
+ * @param opts : *options - The options set by the flags.
+ */
+func addInterpreterFlags(flags *flag.FlagSet, opts *options) {
+	flags.Var(&(*opts).seed, "seed", "Seed of the random number generator for reproducible runs.")
+	flags.BoolVar(&(*opts).sandbox, "sandbox", (*opts).sandbox, "Deny the access to the file system and the environment.")
+	flags.IntVar(&(*opts).maxTraceFrames, "max-trace-frames", (*opts).maxTraceFrames, "Maximum number of calls shown in the call stack of an error (0 for no limit).")
+	flags.BoolVar(&(*opts).traceArguments, "trace-args", (*opts).traceArguments, "Show the arguments of the calls in the call stack of an error.")
+}
+
+/**
+ * Parse the arguments of a command.
+ * @param flags : *flag.FlagSet - The flag set of the command.
+ * @param args : []string - The arguments.
+ * @return int - The exit status if the command must stop (e.g. after --help).
+ * @return bool - True if the command can continue.
+ */
+func parseFlags(flags *flag.FlagSet, args []string) (int, bool) {
+	err := flags.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return EXIT_SUCCESS, false
+	}
+	if err != nil {
+		return EXIT_USAGE_ERROR, false
+	}
+	return EXIT_SUCCESS, true
+}
+
+/**
+ * Check if a flag is given on the command line.
+ * @param flags : *flag.FlagSet - The parsed flag set.
+ * @param name : string - The name of the flag.
+ * @return bool - True if the flag is given.
+ */
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	found := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			found = true
+		}
+	})
+	return found
+}
+
+/**
+ * Get the help of "kode" without a command.
+ * @param flags : *flag.FlagSet - The flag set of "kode".
+ * @return func() - The function printing the help.
+ */
+func mainUsage(flags *flag.FlagSet) func() {
+	return func() {
+		out := flags.Output()
+		fmt.Fprint(out, "Usage: kode <command> [flags] [args...]\n       kode [flags] [file.kd] [args...]\n\nCommands:\n")
+		for _, command := range commands() {
+			fmt.Fprintf(out, "  %-8s %s\n", command.name, command.description)
+		}
+		fmt.Fprint(out, "\nRun \"kode <command> --help\" for the flags of a command.\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+}
+
+/**
+ * Read a source file, or the standard input if the path is "-".
+ * @param path : string - The path of the file.
+ * @param opts : *options - The global options.
+ * @return string - The content of the file.
+ * @return bool - True if the file is read, false if an error is reported.
+ */
+func readSource(path string, opts *options) (string, bool) {
+	var code []byte
+	var err error
+	if path == "-" {
+		code, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		code, err = ioutil.ReadFile(path)
+	}
+
+	if err != nil {
+		opts.printError(kode.CreateError(kode.ErrorMessage(kode.MSG_READ_FILE, path), 0))
+		return "", false
+	}
+	return string(code), true
+}
+
+/**
+ * Run "kode repl": read, run and print code line by line in a single session.
+ * @param args : []string - The arguments after the command.
+ * @param opts : *options - The global options.
+ * @return int - The exit status.
+ */
+func replCommand(args []string, opts *options) int {
+	flags := newFlagSet("repl", "[flags]", "Start an interactive session. Blocks (if, for, func, try) run once closed; type \"exit\" or press Ctrl+D to quit.", opts)
+	addInterpreterFlags(flags, opts)
+
+	if status, ok := parseFlags(flags, args); !ok {
+		return status
+	}
+
+	// Programs read their input from the same stream as the session
+	interpreter := opts.newInterpreter()
+	interpreter.Args = flags.Args()
+	in := bufio.NewReader(os.Stdin)
+	interpreter.SetInput(in)
+	session := interpreter.NewSession()
+
+	interactive := !isPiped(os.Stdin)
+	if interactive {
+		fmt.Println("Kode " + VERSION + " - type \"exit\" or press Ctrl+D to quit.")
+	}
+
+	code := ""
+	for {
+		if interactive {
+			if code == "" {
+				fmt.Print("> ")
+			} else {
+				fmt.Print("... ")
+			}
+		}
+
+		line, err := in.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+
+		if code == "" && strings.TrimSpace(line) == "exit" {
+			return EXIT_SUCCESS
+		}
+		if err == nil || line != "" {
+			code += line + "\n"
+		}
+
+		// Wait for the end of the open blocks
+		if err == nil && !session.IsComplete(code) {
+			continue
+		}
+
+		value, runErr := session.Eval(code)
+		code = ""
+
+		var exitError *kode.ExitError
+		if errors.As(runErr, &exitError) {
+			return exitError.Code
+		} else if runErr != nil {
+			opts.printError(runErr)
+		} else if value != nil && value.Type != "null" {
+			fmt.Println(kode.ReprVariable(*value))
+		}
+
+		if err != nil {
+			if interactive {
+				fmt.Println()
+			}
+			return EXIT_SUCCESS
+		}
+	}
+}
+
+/**
+ * Run "kode check": report the syntax and type errors of Kode files without running them.
+ * @param args : []string - The arguments after the command.
+ * @param opts : *options - The global options.
+ * @return int - The exit status.
+ */
+func checkCommand(args []string, opts *options) int {
+	flags := newFlagSet("check", "[flags] <file.kd>...", "Parse and type-check Kode files without running them (\"-\" reads the standard input).", opts)
+
+	if status, ok := parseFlags(flags, args); !ok {
+		return status
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	status := EXIT_SUCCESS
+	for _, path := range flags.Args() {
+		code, ok := readSource(path, opts)
+		if !ok {
+			status = EXIT_IO_ERROR
+			continue
+		}
+
+		for _, err := range kode.Check(code) {
+			err.SetFile(path)
+			opts.printError(err)
+			if status == EXIT_SUCCESS {
+				status = EXIT_SYNTAX_ERROR
+			}
+		}
+	}
+	return status
+}
+
+/**
+ * Run "kode fmt": format Kode files.
+ * @param args : []string - The arguments after the command.
+ * @param opts : *options - The global options.
+ * @return int - The exit status.
+ */
+func fmtCommand(args []string, opts *options) int {
+	flags := newFlagSet("fmt", "[flags] [file.kd...]", "Format Kode files and print the result. The standard input is formatted if no file is given.", opts)
+	write := flags.Bool("w", false, "Write the result to the files instead of printing it.")
+	list := flags.Bool("l", false, "List the files whose formatting differs.")
+	indent := flags.Int("indent", 4, "Number of spaces per block level.")
+
+	if status, ok := parseFlags(flags, args); !ok {
+		return status
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+
+	status := EXIT_SUCCESS
+	for _, path := range paths {
+		code, ok := readSource(path, opts)
+		if !ok {
+			status = EXIT_IO_ERROR
+			continue
+		}
+
+		formatted := kode.FormatCode(code, strings.Repeat(" ", *indent))
+		if *list && formatted != code {
+			fmt.Println(path)
+		}
+
+		if *write && path != "-" {
+			if formatted == code {
+				continue
+			}
+			info, err := os.Stat(path)
+			if err == nil {
+				err = ioutil.WriteFile(path, []byte(formatted), info.Mode())
+			}
+			if err != nil {
+				opts.printError(kode.CreateError(kode.ErrorMessage(kode.MSG_WRITE_FILE, path), 0))
+				status = EXIT_IO_ERROR
+			}
+		} else if !*list {
+			fmt.Print(formatted)
+		}
+	}
+	return status
+}
+
+/**
+ * Run "kode test": run the functions whose name starts with "test" in the *_test.kd files.
+ * A test fails if it raises an error (e.g. with "assert" or "assertEqual").
+ * @param args : []string - The arguments after the command.
+ * @param opts : *options - The global options.
+ * @return int - The exit status.
+ */
+func testCommand(args []string, opts *options) int {
+	flags := newFlagSet("test", "[flags] [file.kd | directory...]", "Run the test functions (functions whose name starts with \"test\") of the *_test.kd files. Directories are searched recursively (default \".\").", opts)
+	addInterpreterFlags(flags, opts)
+	pattern := flags.String("run", "", "Only run the tests whose name matches the regular expression.")
+	verbose := flags.Bool("v", false, "Show the tests that pass.")
+
+	if status, ok := parseFlags(flags, args); !ok {
+		return status
+	}
+
+	filter, err := regexp.Compile(*pattern)
+	if err != nil {
+		opts.printError(kode.CreateError(kode.ErrorMessage(kode.MSG_INVALID_PATTERN, *pattern, "-run"), 0))
+		return EXIT_USAGE_ERROR
+	}
+
+	paths, ok := findTestFiles(flags.Args(), opts)
+	if !ok {
+		return EXIT_IO_ERROR
+	}
+	if len(paths) == 0 {
+		fmt.Println("No test files found.")
+		return EXIT_SUCCESS
+	}
+
+	passed, failed := 0, 0
+	for _, path := range paths {
+		code, ok := readSource(path, opts)
+		if !ok {
+			return EXIT_IO_ERROR
+		}
+
+		// The tests of a file share the variables and functions declared by the file
+		interpreter := opts.newInterpreter()
+		interpreter.File = path
+		session := interpreter.NewSession()
+		if _, err := session.Eval(code); err != nil {
+			opts.printTestFailure(path, err)
+			failed++
+			continue
+		}
+
+		for _, doc := range kode.ExtractDocs(code) {
+			if !strings.HasPrefix(doc.Name, "test") || strings.Contains(doc.Name, ".") || !filter.MatchString(doc.Name) {
+				continue
+			}
+
+			if _, err := session.Eval(doc.Name + "()"); err != nil {
+				opts.printTestFailure(path+": "+doc.Name, err)
+				failed++
+			} else {
+				if *verbose {
+					fmt.Printf("--- PASS: %s: %s\n", path, doc.Name)
+				}
+				passed++
+			}
+		}
+	}
+
+	fmt.Printf("%d passed, %d failed\n", passed, failed)
+	if failed > 0 {
+		return EXIT_RUNTIME_ERROR
+	}
+	return EXIT_SUCCESS
+}
+
+/**
+ * Find the test files (*_test.kd) of files and directories.
+ * @param paths : []string - The files and directories (default ".").
+ * @param opts : *options - The global options.
+ * @return []string - The test files.
+ * @return bool - True if every path can be read.
+ */
+func findTestFiles(paths []string, opts *options) ([]string, bool) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	files := []string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			opts.printError(kode.CreateError(kode.ErrorMessage(kode.MSG_FIND_FILE, path), 0))
+			return nil, false
+		}
+
+		// Files given explicitly are tests even without the suffix
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && strings.HasSuffix(info.Name(), "_test.kd") {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			opts.printError(kode.CreateError(kode.ErrorMessage(kode.MSG_LIST_DIRECTORY, path), 0))
+			return nil, false
+		}
+	}
+	return files, true
+}
+
+/**
+ * Get the message of a failed test.
+ * @param err : error - The error of the test.
+ * @param language : string - The language of the message (see kode.LANGUAGES).
+ * @return string - The message.
+ */
+func testError(err error, language string) string {
+	var exitError *kode.ExitError
+	if errors.As(err, &exitError) {
+		return kode.ErrorMessage(kode.MSG_TEST_EXIT, strconv.Itoa(exitError.Code)).In(language)
+	}
+
+	// The cause is enough to explain an assertion
+	var errorStack *kode.ErrorStack
+	if errors.As(err, &errorStack) {
+		cause := errorStack.Cause()
+		cause.Localize(language)
+		return cause.Summary()
+	}
+	return err.Error()
+}
+
+/**
+ * Print a failed test. With the JSON error format, the error is printed as a diagnostic (see printError).
+ * @param name : string - The name of the test (e.g. "math_test.kd: testAdd"), or of the file if it cannot run.
+ * @param err : error - The error of the test.
+ */
+func (opts *options) printTestFailure(name string, err error) {
+	if (*opts).errorFormat != "json" {
+		fmt.Printf("--- FAIL: %s\n    %s\n", name, indentLines(testError(err, (*opts).language)))
+		return
+	}
+
+	fmt.Printf("--- FAIL: %s\n", name)
+	var exitError *kode.ExitError
+	if errors.As(err, &exitError) {
+		err = kode.CreateError(kode.ErrorMessage(kode.MSG_TEST_EXIT, strconv.Itoa(exitError.Code)), 0)
+	}
+	opts.printError(err)
+}
+
+/**
+ * Indent the lines of a message after the first one.
+ * @param message : string - The message.
+ * @return string - The indented message.
+ */
+func indentLines(message string) string {
+	return strings.ReplaceAll(strings.TrimRight(message, "\n"), "\n", "\n    ")
+}
+
+/**
+ * Run "kode doc": print the declaration and comment of the functions of Kode files.
+ * @param args : []string - The arguments after the command.
+ * @param opts : *options - The global options.
+ * @return int - The exit status.
+ */
+func docCommand(args []string, opts *options) int {
+	flags := newFlagSet("doc", "[flags] <file.kd>...", "Show the functions of Kode files with the comment lines right above their declaration.", opts)
+
+	if status, ok := parseFlags(flags, args); !ok {
+		return status
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return EXIT_USAGE_ERROR
+	}
+
+	for i, path := range flags.Args() {
+		code, ok := readSource(path, opts)
+		if !ok {
+			return EXIT_IO_ERROR
+		}
+
+		if flags.NArg() > 1 {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(path + ":")
+			fmt.Println()
+		}
+
+		for _, doc := range kode.ExtractDocs(code) {
+			// Methods are shown with the name of their object (e.g. func Point.norm() float)
+			signature := doc.Signature
+			if index := strings.LastIndex(doc.Name, "."); index >= 0 {
+				signature = strings.Replace(signature, doc.Name[index+1:], doc.Name, 1)
+			}
+
+			fmt.Println(signature)
+			if doc.Comment != "" {
+				fmt.Println("    " + strings.ReplaceAll(doc.Comment, "\n", "\n    "))
+			}
+			fmt.Println()
+		}
+	}
+	return EXIT_SUCCESS
+}
+
+/**
+ * Run "kode version": show the current version.
+ * @param args : []string - The arguments after the command.
+ * @param opts : *options - The global options.
+ * @return int - The exit status.
+ */
+func versionCommand(args []string, opts *options) int {
+	flags := newFlagSet("version", "", "Show the current version of Kode.", opts)
+
+	if status, ok := parseFlags(flags, args); !ok {
+		return status
+	}
+
+	fmt.Println("Kode version: " + VERSION)
+	fmt.Println("Created by Eduard A. and Frédéric J.")
+	fmt.Println("Make sure to check out the GitHub repository: https://github.com/PersoSirEduard/Kode")
+	return EXIT_SUCCESS
+}
+
+/**
+ * Run "kode help": show the help of a command.
+ * @param args : []string - The arguments after the command.
+ * @param opts : *options - The global options.
+ * @return int - The exit status.
+ */
+func helpCommand(args []string, opts *options) int {
+	if len(args) > 0 {
+		if command, found := findCommand(args[0]); found && command.name != "help" {
+			return command.run([]string{"-help"}, opts)
+		}
+		opts.printError(kode.CreateError(kode.ErrorMessage(kode.MSG_UNKNOWN_COMMAND, args[0]), 0))
+		return EXIT_USAGE_ERROR
+	}
+
+	flags := newFlagSet("kode", "", "", opts)
+	addInterpreterFlags(flags, opts)
+	addRunFlags(flags)
+	mainUsage(flags)()
+	return EXIT_SUCCESS
+}
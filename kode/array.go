@@ -2,9 +2,43 @@ package kode
 
 import (
 	"math"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
+// ! Array : The storage of an array value.
+// Arrays are references: every variable holding the same array shares its elements and its size.
+// -------------------------
+// ! Elements : The elements of the array.
+// -------------------------
+// ! Frozen : The elements cannot be modified, through any variable holding the array (e.g. "freeze()").
+// -------------------------
+// ! Constant : The array is frozen because it belongs to a constant or a read-only parameter. The variables declared
+// from it get a modifiable copy (e.g. int[] row = matrix[0]).
+type Array struct {
+	Elements []Variable
+	Frozen   bool
+	Constant bool
+}
+
+/**
+ * Get the elements of an array variable.
+ * @return []Variable - The elements of the array.
+ */
+func (variable *Variable) Elements() []Variable {
+	return (*variable).Value.(*Array).Elements
+}
+
+/**
+ * Check if a variable holds a frozen array.
+ * @return bool - True if the elements of the array cannot be modified.
+ */
+func (variable *Variable) IsFrozen() bool {
+	array, ok := (*variable).Value.(*Array)
+	return ok && array.Frozen
+}
+
 /**
  * Evaluate the type of an array variable.
  * @param value : interface{} - The array
@@ -32,21 +66,22 @@ func EvaluateArrayType(array []Variable) string {
 
 /**
  * Extract the values of a declared array.
- * @param queue : *Queue - The queue to extract the values from.
+ * @param tokens : *Tokens - The tokens to extract the values from, after the opening bracket.
  * @return []Variable - Resulting array.
  * @return error - The error if one occurs.
  */
-func (scope *Function) ExtractArrayValues(queue *Queue, depth int64, startLine int) ([]Variable, *ErrorStack) {
+func (scope *Function) ExtractArrayValues(tokens *Tokens, depth int64, startLine int) ([]Variable, *ErrorStack) {
 
 	// Extract the array's value
+	opening := tokens.Last()
 	nestedArray := 0
 	closedArray := false
 	isInString := false
-	tmpVal := ""
+	first := tokens.Index() // The index of the first token of the value
 	array := []Variable{}
 
-	for !queue.IsEmpty() {
-		nextToken, _ := queue.Pop()
+	for !tokens.IsEmpty() {
+		nextToken, _ := tokens.Pop()
 
 		if nextToken.(string) == "[" && !isInString {
 			nestedArray++
@@ -60,32 +95,29 @@ func (scope *Function) ExtractArrayValues(queue *Queue, depth int64, startLine i
 		}
 		if nextToken.(string) == "," && nestedArray == 0 && !isInString {
 			// Evaluate the parameter
-			//println(tmpVal)
-			parameter, err := EvaluateExpression(scope, tmpVal, depth, startLine)
+			span := tokens.Between(first, tokens.Index()-1)
+			parameter, err := EvaluateExpression(scope, tokens.Text(span), depth, startLine, span.Start)
 			if err != nil {
 				return []Variable{}, err
 			}
 
 			array = append(array, parameter)
-			tmpVal = ""
+			first = tokens.Index()
 			continue
 		}
 
 		if nextToken.(string) == "\"" {
 			isInString = !isInString
 		}
-
-		if isInString {
-			tmpVal += nextToken.(string)
-		} else {
-			tmpVal += nextToken.(string) + " "
-		}
 	}
 
 	// Add last value
-	if tmpVal != "" {
-		//println(tmpVal)
-		parameter, err := EvaluateExpression(scope, tmpVal, depth, startLine)
+	last := tokens.Index()
+	if closedArray {
+		last--
+	}
+	if span := tokens.Between(first, last); !span.IsEmpty() {
+		parameter, err := EvaluateExpression(scope, tokens.Text(span), depth, startLine, span.Start)
 		if err != nil {
 			return []Variable{}, err
 		}
@@ -93,7 +125,7 @@ func (scope *Function) ExtractArrayValues(queue *Queue, depth int64, startLine i
 	}
 
 	if !closedArray {
-		return []Variable{}, CreateError("Error: Array not closed", startLine)
+		return []Variable{}, CreateError(ErrorMessage(MSG_ARRAY_NOT_CLOSED), startLine).At(opening)
 	}
 
 	return array, nil
@@ -138,11 +170,25 @@ func ExtractArrayDimensionFromDeclaration(tokens *Queue, startLine int) (int, *E
 	// Update the type according to dimmension
 	_, fraction := math.Modf(dimension)
 	if fraction != 0 {
-		return 0, CreateError("Error: Invalid array dimension at declaration", startLine)
+		return 0, CreateError(ErrorMessage(MSG_ARRAY_DIMENSION), startLine)
 	}
 	return int(dimension), nil
 }
 
+/**
+ * Check if a token is the name of a declarable type.
+ * @param name : string - The token to check.
+ * @return bool - True if the token is a type name.
+ */
+func IsTypeName(name string) bool {
+	switch name {
+	case "val", "int", "float", "string", "bool":
+		return true
+	default:
+		return false
+	}
+}
+
 /**
  * Check if the type is an array type.
  * @param strType : string - The type to check.
@@ -160,22 +206,59 @@ func isArrayType(strType string) bool {
  */
 func GetArraySize(variable *Variable, startLine int) (int64, *ErrorStack) {
 	if isArrayType(variable.Type) {
+		return int64(len(variable.Elements())), nil
+	} else if variable.Type == "string" {
+		return int64(utf8.RuneCountInString((*variable).Value.(string))), nil
+	} else {
+		return 0, CreateError(ErrorMessage(MSG_ARRAY_SIZE), startLine)
+	}
+}
 
-		size := int64(len((*variable).Value.([]Variable)))
-		if size == 0 {
-			return 0, CreateError("Error: Array is empty", startLine)
-		}
-		return size, nil
+/**
+ * Check if the scope uses bounds-checked indexing ("_STRICT_INDEX"). Strict indexing is the default.
+ * @return bool - True if out-of-bounds indexes raise an error, false if they wrap around.
+ */
+func (scope *Function) IsStrictIndex() bool {
+	strict := (*scope).GetVariable("_STRICT_INDEX")
+	if strict == nil || (*strict).Type != "bool" {
+		return true
+	}
+	return (*strict).Value.(bool)
+}
 
-	} else if variable.Type == "string" {
+/**
+ * Resolve an index of an array or a string. Negative indexes count from the end.
+ * Out-of-bounds indexes raise an error, unless "_STRICT_INDEX" is false in which case they wrap around the size.
+ * @param index : int64 - The index to resolve.
+ * @param size : int64 - The size of the array or string.
+ * @return int64 - The resolved index.
+ * @return error - The error if one occurs.
+ */
+func (scope *Function) ResolveIndex(index int64, size int64, startLine int) (int64, *ErrorStack) {
+
+	if !scope.IsStrictIndex() {
 
-		size := int64(len((*variable).Value.(string)))
+		// Legacy behaviour, wrap the index around the size
 		if size == 0 {
-			return 0, CreateError("Error: String is empty", startLine)
+			return 0, CreateError(ErrorMessage(MSG_EMPTY_INDEX, strconv.FormatInt(index, 10)), startLine)
 		}
-		return size, nil
 
-	} else {
-		return 0, CreateError("Error: Cannot get array size of non-array type", startLine)
+		index = index % size
+		if index < 0 {
+			index += size
+		}
+		return index, nil
 	}
+
+	// Negative indexes count from the end
+	resolved := index
+	if resolved < 0 {
+		resolved += size
+	}
+
+	if resolved < 0 || resolved >= size {
+		return 0, CreateError(ErrorMessage(MSG_INDEX_OUT, strconv.FormatInt(index, 10), strconv.FormatInt(size, 10)), startLine)
+	}
+
+	return resolved, nil
 }
@@ -0,0 +1,49 @@
+package kode
+
+/**
+ * Fail if a condition is false (e.g. in the tests run by "kode test").
+ * @param args :[]*Variable - The condition and an optional message.
+ * @return *Variable - Null.
+ * @return error - The failed assertion.
+**/
+func Assert(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 && len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "1 or 2", "assert"), startLine)
+	}
+
+	if args[0].Type != "bool" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "a bool", "assert"), startLine)
+	}
+
+	if !args[0].Value.(bool) {
+		message := ErrorMessage(MSG_ASSERTION)
+		if len(args) == 2 {
+			message = ErrorMessage(MSG_ASSERTION_MESSAGE, FormatVariable(*args[1]))
+		}
+		return NullVariable(), CreateError(message, startLine)
+	}
+	return NullVariable(), nil
+}
+
+/**
+ * Fail if two values are different. Values are equal if they have the same type and the same representation
+ * (arrays and objects are compared element by element).
+ * @param args :[]*Variable - The actual value, the expected value and an optional message.
+ * @return *Variable - Null.
+ * @return error - The failed assertion.
+**/
+func AssertEqual(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 && len(args) != 3 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2 or 3", "assertEqual"), startLine)
+	}
+
+	actual, expected := ReprVariable(*args[0]), ReprVariable(*args[1])
+	if args[0].Type != args[1].Type || actual != expected {
+		message := ErrorMessage(MSG_ASSERT_EQUAL, expected, args[1].Type, actual, args[0].Type)
+		if len(args) == 3 {
+			message = ErrorMessage(MSG_ASSERT_EQUAL_MSG, expected, args[1].Type, actual, args[0].Type, FormatVariable(*args[2]))
+		}
+		return NullVariable(), CreateError(message, startLine)
+	}
+	return NullVariable(), nil
+}
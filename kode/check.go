@@ -0,0 +1,506 @@
+package kode
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Literal values whose type is known without running the code
+var (
+	intLiteralFormat   = regexp.MustCompile(`^[0-9]+$`)
+	floatLiteralFormat = regexp.MustCompile(`^([0-9]+\.[0-9]*|\.[0-9]+)$`)
+)
+
+// ! checkBlock : A block (or the main scope) opened while checking code.
+// -------------------------
+// ! Kind : "main", "if", "for", "func" or "try".
+// -------------------------
+// ! Name : The name of the function for a "func" block.
+// -------------------------
+// ! Variables : The types of the variables declared in the block ("const" is added for constants).
+type checkBlock struct {
+	Kind      string
+	Name      string
+	Line      int
+	Variables map[string]string
+}
+
+/**
+ * Check code without running it: blocks must be closed, declarations must be well-formed and literal values must
+ * match the declared types. Every error found is reported.
+ * @param code : string - The code to check.
+ * @return []*ErrorStack - The errors, sorted by line.
+ */
+func Check(code string) []*ErrorStack {
+	return checkCode(code, "")
+}
+
+/**
+ * Check code without running it (see Check).
+ * @param code : string - The code to check.
+ * @param keywords : string - The language of the keywords if the code has no pragma (see KEYWORD_ALIASES).
+ * @return []*ErrorStack - The errors, sorted by line.
+ */
+func checkCode(code string, keywords string) []*ErrorStack {
+	errors := []*ErrorStack{}
+	blocks := []*checkBlock{{Kind: "main", Variables: map[string]string{}}}
+	for _, name := range SYSTEM_VARIABLES {
+		blocks[0].Variables[name] = "val"
+	}
+
+	lines := LineParse(strings.ReplaceAll(code, "\r", " "))
+
+	// The keywords can be written in another language
+	if pragma, line := keywordsPragma(code); pragma != "" && pragma != "en" && KEYWORD_ALIASES[pragma] == nil {
+		start := strings.LastIndex(lines[line-1], pragma)
+		err := CreateSyntaxError(ErrorMessage(MSG_UNKNOWN_KEYWORDS, pragma), line).At(Span{start, start + len(pragma)})
+		errors = append(errors, err.WithHint(didYouMean(pragma, LANGUAGES)))
+	}
+	aliases := codeKeywords(code, keywords)
+
+	for i, line := range lines {
+		lineNumber := i + 1
+		if i == 0 && strings.HasPrefix(line, "#!") {
+			continue
+		}
+
+		tokens, spans := LineSpans(translateKeywords(line, aliases))
+		if len(tokens) == 0 {
+			continue
+		}
+
+		if err := checkLineTokens(tokens, spans, lineNumber); err != nil {
+			errors = append(errors, err)
+
+			// Still open the block of the line so that its "end" matches
+			switch tokens[0] {
+			case "if", "for", "func", "try":
+				block := &checkBlock{Kind: tokens[0], Line: lineNumber, Variables: map[string]string{}}
+				if tokens[0] == "func" && len(tokens) > 1 {
+					block.Name = tokens[1]
+				}
+				blocks = append(blocks, block)
+			}
+			continue
+		}
+
+		current := blocks[len(blocks)-1]
+		switch tokens[0] {
+
+		case "if", "for":
+			if len(tokens) == 1 {
+				errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_MISSING_CONDITION, tokens[0]), lineNumber))
+			}
+			block := &checkBlock{Kind: tokens[0], Line: lineNumber, Variables: map[string]string{}}
+			if name, _, isForEach := ParseForEach(strings.Join(tokens[1:], " ")); tokens[0] == "for" && isForEach {
+				if !HasValidVariableName(name) {
+					errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_VARIABLE_NAME, name), lineNumber))
+				}
+				block.Variables[name] = "val"
+			}
+			blocks = append(blocks, block)
+
+		case "else":
+			if current.Kind != "if" {
+				errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_UNEXPECTED_ELSE), lineNumber))
+			} else if len(tokens) > 1 && tokens[1] == "if" && len(tokens) == 2 {
+				errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_MISSING_CONDITION, "else if"), lineNumber))
+			} else {
+				// The variables of the previous branch are not visible
+				current.Variables = map[string]string{}
+			}
+
+		case "try":
+			if len(tokens) > 1 {
+				errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_UNEXPECTED_AFTER, strings.Join(tokens[1:], " "), "try"), lineNumber))
+			}
+			blocks = append(blocks, &checkBlock{Kind: "try", Line: lineNumber, Variables: map[string]string{}})
+
+		case "catch":
+			if current.Kind != "try" {
+				errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_UNEXPECTED_CATCH), lineNumber))
+				break
+			}
+			current.Variables = map[string]string{}
+			if len(tokens) > 2 {
+				errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_EXPECTED_CATCH), lineNumber))
+			} else if len(tokens) == 2 {
+				if !HasValidVariableName(tokens[1]) {
+					errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_VARIABLE_NAME, tokens[1]), lineNumber))
+				}
+				current.Variables[tokens[1]] = "val"
+			}
+
+		case "func":
+			block, err := checkFunctionDeclaration(tokens, spans, lineNumber)
+			if err != nil {
+				errors = append(errors, err)
+			}
+			if block.Name != "" {
+				current.Variables[block.Name] = "func"
+			}
+			blocks = append(blocks, block)
+
+		case "end":
+			if len(tokens) < 2 {
+				errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_MISSING_END_NAME), lineNumber))
+				break
+			}
+			if len(blocks) == 1 {
+				errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_UNEXPECTED_BLOCK_END, tokens[1]), lineNumber))
+				break
+			}
+			if expected := blockEnd(current); tokens[1] != expected {
+				err := CreateSyntaxError(ErrorMessage(MSG_MISMATCHED_END, expected, tokens[1]), lineNumber).At(spans[1])
+				if Suggest(tokens[1], []string{expected}) != "" {
+					err.WithHint(NewMessage(MSG_DID_YOU_MEAN, "end "+expected))
+				}
+				errors = append(errors, err)
+
+				// Recover by closing the blocks up to the matching one (if any)
+				for j := len(blocks) - 2; j > 0; j-- {
+					if blockEnd(blocks[j]) == tokens[1] {
+						blocks = blocks[:j+1]
+						break
+					}
+				}
+			}
+			blocks = blocks[:len(blocks)-1]
+
+		case "break":
+			inLoop := false
+			for j := len(blocks) - 1; j > 0 && blocks[j].Kind != "func"; j-- {
+				inLoop = inLoop || blocks[j].Kind == "for"
+			}
+			if !inLoop {
+				errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_BREAK_OUTSIDE), lineNumber))
+			}
+
+		case "return":
+			// Returns are allowed everywhere (returning from the main scope ends the program)
+
+		case "val", "int", "float", "string", "bool", "const":
+			if err := checkDeclaration(tokens, spans, blocks, lineNumber); err != nil {
+				errors = append(errors, err)
+			}
+
+		default:
+			if err := checkUnknownKeyword(tokens, spans, lineNumber); err != nil {
+				errors = append(errors, err)
+			} else if err := checkAssignment(tokens, spans, blocks, lineNumber); err != nil {
+				errors = append(errors, err)
+			}
+		}
+	}
+
+	// Every block must be closed
+	for _, block := range blocks[1:] {
+		errors = append(errors, CreateSyntaxError(ErrorMessage(MSG_UNCLOSED_BLOCK, block.Kind, blockEnd(block)), block.Line))
+	}
+
+	// Point the errors at their code
+	for _, err := range errors {
+		err.Locate(lines, aliases)
+	}
+
+	sort.SliceStable(errors, func(a int, b int) bool {
+		return errors[a].Line < errors[b].Line
+	})
+	return errors
+}
+
+/**
+ * Check the syntax of code before running it: every syntax error of the code is reported at once (see Check),
+ * while the errors depending on the values (e.g. unknown variables) are left to the run.
+ * @param code : string - The code to check.
+ * @return []*ErrorStack - The syntax errors, sorted by line.
+ */
+func CheckSyntax(code string) []*ErrorStack {
+	return syntaxErrors(Check(code))
+}
+
+/**
+ * Keep the syntax errors of a list of errors.
+ * @param all : []*ErrorStack - The errors.
+ * @return []*ErrorStack - The syntax errors, in the same order.
+ */
+func syntaxErrors(all []*ErrorStack) []*ErrorStack {
+	errors := []*ErrorStack{}
+	for _, err := range all {
+		if (*err).Kind == SYNTAX_ERROR {
+			errors = append(errors, err)
+		}
+	}
+	return errors
+}
+
+/**
+ * Get the name closing a block (e.g. "if" for "end if" or the name of a function).
+ * @param block : *checkBlock - The block.
+ * @return string - The name after "end".
+ */
+func blockEnd(block *checkBlock) string {
+	if block.Kind == "func" {
+		return block.Name
+	}
+	return block.Kind
+}
+
+/**
+ * Check that the strings, parentheses and brackets of a line are closed.
+ * @param tokens : []string - The tokens of the line.
+ * @param spans : []Span - The span of each token.
+ * @param line : int - The line number.
+ * @return *ErrorStack - The error, or nil.
+ */
+func checkLineTokens(tokens []string, spans []Span, line int) *ErrorStack {
+	parentheses, brackets := []int{}, []int{} // The indexes of the opening tokens left to close
+	for i, token := range tokens {
+		isUnexpected := false
+		switch {
+		case strings.HasPrefix(token, "\""):
+			if len(token) < 2 || !strings.HasSuffix(token, "\"") || strings.HasSuffix(token, "\\\"") {
+				return CreateSyntaxError(ErrorMessage(MSG_UNCLOSED_STRING), line).At(spans[i])
+			}
+		case token == "(":
+			parentheses = append(parentheses, i)
+		case token == ")":
+			isUnexpected = len(parentheses) == 0
+			if !isUnexpected {
+				parentheses = parentheses[:len(parentheses)-1]
+			}
+		case token == "[":
+			brackets = append(brackets, i)
+		case token == "]":
+			isUnexpected = len(brackets) == 0
+			if !isUnexpected {
+				brackets = brackets[:len(brackets)-1]
+			}
+		}
+		if isUnexpected {
+			return CreateSyntaxError(ErrorMessage(MSG_UNEXPECTED, token), line).At(spans[i])
+		}
+	}
+
+	if len(parentheses) > 0 {
+		return CreateSyntaxError(ErrorMessage(MSG_MISSING_CLOSING), line).At(spans[parentheses[0]])
+	}
+	if len(brackets) > 0 {
+		return CreateSyntaxError(ErrorMessage(MSG_MISSING_BRACKET), line).At(spans[brackets[0]])
+	}
+	return nil
+}
+
+/**
+ * Check a function declaration, e.g. "func add(int a, readonly int[] b) int".
+ * @param tokens : []string - The tokens of the line.
+ * @param spans : []Span - The span of each token.
+ * @param line : int - The line number.
+ * @return *checkBlock - The block of the function with its parameters.
+ * @return *ErrorStack - The error, or nil.
+ */
+func checkFunctionDeclaration(tokens []string, spans []Span, line int) (*checkBlock, *ErrorStack) {
+	block := &checkBlock{Kind: "func", Line: line, Variables: map[string]string{}}
+
+	if len(tokens) < 2 {
+		return block, CreateSyntaxError(ErrorMessage(MSG_MISSING_FUNCTION), line)
+	}
+	block.Name = tokens[1]
+	if !HasValidVariableName(tokens[1]) {
+		return block, CreateSyntaxError(ErrorMessage(MSG_FUNCTION_NAME, tokens[1]), line).At(spans[1])
+	}
+	if len(tokens) < 3 || tokens[2] != "(" {
+		return block, CreateSyntaxError(ErrorMessage(MSG_PARAMETERS_START), line)
+	}
+
+	// Parameters: [readonly|let] type[[]...] name, separated by commas
+	i := 3
+	for i < len(tokens) && tokens[i] != ")" {
+		if tokens[i] == "readonly" || tokens[i] == "let" {
+			i++
+		}
+		if i >= len(tokens) {
+			return block, CreateSyntaxError(ErrorMessage(MSG_EXPECTED_TYPE), line)
+		}
+		if !IsTypeName(tokens[i]) {
+			return block, CreateSyntaxError(ErrorMessage(MSG_PARAMETER_TYPE, tokens[i]), line).At(spans[i])
+		}
+		typeName := tokens[i]
+		i++
+		for i+1 < len(tokens) && tokens[i] == "[" && tokens[i+1] == "]" {
+			typeName += "[]"
+			i += 2
+		}
+		if i >= len(tokens) {
+			return block, CreateSyntaxError(ErrorMessage(MSG_EXPECTED_PARAMETER), line)
+		}
+		if !HasValidVariableName(tokens[i]) {
+			return block, CreateSyntaxError(ErrorMessage(MSG_PARAMETER_NAME, tokens[i]), line).At(spans[i])
+		}
+		block.Variables[tokens[i]] = typeName
+		i++
+		if i < len(tokens) && tokens[i] == "," {
+			i++
+		} else if i >= len(tokens) || tokens[i] != ")" {
+			return block, CreateSyntaxError(ErrorMessage(MSG_EXPECTED_COMMA), line)
+		}
+	}
+	if i >= len(tokens) {
+		return block, CreateSyntaxError(ErrorMessage(MSG_EXPECTED_CLOSING), line)
+	}
+
+	// Optional return type
+	if i+1 < len(tokens) {
+		returnType := strings.Join(tokens[i+1:], "")
+		if !IsTypeName(strings.ReplaceAll(returnType, "[]", "")) && returnType != "func" {
+			return block, CreateSyntaxError(ErrorMessage(MSG_RETURN_TYPE, returnType), line).At(spans[i+1].To(spans[len(spans)-1]))
+		}
+	}
+
+	// A function can call itself
+	block.Variables[block.Name] = "func"
+	return block, nil
+}
+
+/**
+ * Check a variable declaration, e.g. "const int[] x = [1, 2]".
+ * @param tokens : []string - The tokens of the line.
+ * @param spans : []Span - The span of each token.
+ * @param blocks : []*checkBlock - The open blocks.
+ * @param line : int - The line number.
+ * @return *ErrorStack - The error, or nil.
+ */
+func checkDeclaration(tokens []string, spans []Span, blocks []*checkBlock, line int) *ErrorStack {
+	typeName := tokens[0]
+	i := 1
+	if typeName == "const" {
+		typeName = "val"
+		if i < len(tokens) && IsTypeName(tokens[i]) {
+			typeName = tokens[i]
+			i++
+		}
+	}
+	for i+1 < len(tokens) && tokens[i] == "[" && tokens[i+1] == "]" {
+		typeName += "[]"
+		i += 2
+	}
+
+	if i >= len(tokens) {
+		return CreateSyntaxError(ErrorMessage(MSG_MISSING_NAME), line)
+	}
+	name := tokens[i]
+	if !HasValidVariableName(name) {
+		return CreateSyntaxError(ErrorMessage(MSG_VARIABLE_NAME, name), line).At(spans[i])
+	}
+
+	current := blocks[len(blocks)-1]
+	if _, exists := current.Variables[name]; exists {
+		return CreateError(ErrorMessage(MSG_VARIABLE_EXISTS, name), line).At(spans[i])
+	}
+	current.Variables[name] = typeName
+	if tokens[0] == "const" {
+		current.Variables[name] = "const"
+	}
+
+	if i+1 >= len(tokens) || tokens[i+1] != "=" {
+		return CreateSyntaxError(ErrorMessage(MSG_MISSING_ASSIGN, name), line)
+	}
+	if i+2 >= len(tokens) {
+		return CreateSyntaxError(ErrorMessage(MSG_MISSING_VALUE, name), line)
+	}
+
+	if literalType := LiteralType(strings.Join(tokens[i+2:], "")); typeName != "val" && literalType != "" && literalType != typeName {
+		return CreateError(ErrorMessage(MSG_TYPE_MISMATCH, name, typeName), line).At(spans[i+2].To(spans[len(spans)-1]))
+	}
+	return nil
+}
+
+/**
+ * Check that a line does not start with an unknown keyword, i.e. a name followed by another name or a value
+ * (e.g. "retrun 5" or "whlie x < 3").
+ * @param tokens : []string - The tokens of the line.
+ * @param spans : []Span - The span of each token.
+ * @param line : int - The line number.
+ * @return *ErrorStack - The error, or nil.
+ */
+func checkUnknownKeyword(tokens []string, spans []Span, line int) *ErrorStack {
+	if len(tokens) < 2 || !HasValidVariableName(tokens[0]) || isOperator(tokens[0]) {
+		return nil
+	}
+
+	next := tokens[1]
+	if !(varFormat.MatchString(next) && !isOperator(next)) && LiteralType(next) == "" && !strings.HasPrefix(next, "\"") {
+		return nil
+	}
+
+	hint := didYouMean(tokens[0], STATEMENT_KEYWORDS)
+	return CreateSyntaxError(ErrorMessage(MSG_UNKNOWN_COMMAND, tokens[0]), line).At(spans[0]).WithHint(hint)
+}
+
+/**
+ * Check an assignment to a variable declared in the checked code, e.g. "x = 2".
+ * Other lines (e.g. function calls or assignments to fields) are not checked.
+ * @param tokens : []string - The tokens of the line.
+ * @param spans : []Span - The span of each token.
+ * @param blocks : []*checkBlock - The open blocks.
+ * @param line : int - The line number.
+ * @return *ErrorStack - The error, or nil.
+ */
+func checkAssignment(tokens []string, spans []Span, blocks []*checkBlock, line int) *ErrorStack {
+	if len(tokens) < 2 || tokens[1] != "=" {
+		return nil
+	}
+
+	name := tokens[0]
+	if !HasValidVariableName(name) {
+		return CreateSyntaxError(ErrorMessage(MSG_INVALID_ASSIGNMENT, name), line)
+	}
+	if len(tokens) == 2 {
+		return CreateSyntaxError(ErrorMessage(MSG_EMPTY_VALUE), line)
+	}
+
+	for i := len(blocks) - 1; i >= 0; i-- {
+		typeName, exists := blocks[i].Variables[name]
+		if !exists {
+			continue
+		}
+		if typeName == "const" {
+			return CreateError(ErrorMessage(MSG_CONSTANT, name), line).At(spans[0].To(spans[len(spans)-1]))
+		}
+		if literalType := LiteralType(strings.Join(tokens[2:], "")); typeName != "val" && literalType != "" && literalType != typeName {
+			return CreateError(ErrorMessage(MSG_ASSIGNMENT_TYPE, typeName, literalType), line).At(spans[2].To(spans[len(spans)-1]))
+		}
+		return nil
+	}
+
+	known := []string{}
+	for _, block := range blocks {
+		for variable := range block.Variables {
+			known = append(known, variable)
+		}
+	}
+	return CreateError(ErrorMessage(MSG_UNKNOWN_VARIABLE, name), line).At(spans[0]).WithHint(didYouMean(name, known))
+}
+
+/**
+ * Get the type of a literal value.
+ * @param value : string - The value without spaces (e.g. "12", "1.5", "\"abc\"", "true" or "null").
+ * @return string - The type of the literal, or "" if the value is not a literal.
+ */
+func LiteralType(value string) string {
+	switch {
+	case intLiteralFormat.MatchString(value):
+		return "int"
+	case floatLiteralFormat.MatchString(value):
+		return "float"
+	case value == "true" || value == "false":
+		return "bool"
+	case value == "null":
+		return "null"
+	case len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") && strings.Count(value, "\"")-strings.Count(value, "\\\"") == 2:
+		return "string"
+	default:
+		return ""
+	}
+}
@@ -4,32 +4,36 @@ package kode
 // -------------------------
 // ! Condition : The condition of the block.
 // -------------------------
+// ! ConditionOffset : The byte offset of the condition in its line.
+// -------------------------
 // ! ConditionIndex : The line number of the condition.
 // -------------------------
 // ! Code : The code of the block.
 type ConditionBlock struct {
-	Condition      string
-	ConditionIndex int
-	Code           string
+	Condition       string
+	ConditionOffset int
+	ConditionIndex  int
+	Code            string
 }
 
 /**
  * Parse the conditions block(s).
- * @param tokens : *Queue - The tokens to parse.
+ * @param tokens : *Tokens - The tokens to parse.
  * @param currentLine : int - The current line number of the first "if" token.
  * @param lines : []string - The lines of the current scope.
+ * @param startLine : int - The line of the program of the first line of the scope.
  * @return []ConditionBlock - The parsed conditions block(s).
  * @return int - The ending index line of the condition block(s).
  * @return error - The error if any.
  */
-func ParseConditionBlocks(tokens *Queue, currentLine int, lines []string, startLine int) ([]ConditionBlock, int, *ErrorStack) {
+func ParseConditionBlocks(tokens *Tokens, currentLine int, lines []string, startLine int) ([]ConditionBlock, int, *ErrorStack) {
 
 	// Get the condition as a string
-	// Get the rest of the line tokens and join them to feed the condition
-	condition := InlineQueueToString(tokens)
+	// Get the rest of the line tokens to feed the condition
+	condition := tokens.Rest()
 
 	// Array of the condition blocks. Store the current line number, the condition and the code
-	conditionBlocks := append([]ConditionBlock{}, ConditionBlock{condition, currentLine, ""})
+	conditionBlocks := append([]ConditionBlock{}, ConditionBlock{tokens.Text(condition), condition.Start, currentLine, ""})
 	currentLine++          // Skip to next line to avoid including the condition in the code
 	foundBoundary := false // Flag to indicate if the boundary of the condition has been found
 	nestedBlocksCount := 0 // Keep track of the number of nested blocks
@@ -49,20 +53,14 @@ func ParseConditionBlocks(tokens *Queue, currentLine int, lines []string, startL
 			// Same level of nesting condition "else if"
 		} else if len(parsed) > 1 && parsed[0] == "else" && parsed[1] == "if" && nestedBlocksCount == 0 {
 
-			// Get the rest of the line tokens and join them to feed the condition
-			// Not so elegant, but it works
-			ifElseCondition := ""
-			for i := 2; i < len(parsed); i++ {
-				if parsed[i] == " " {
-					ifElseCondition += " "
-				} else {
-					// Don't add an extra space if the token is just a space
-					ifElseCondition += parsed[i] + " "
-				}
-			}
+			// Get the rest of the line tokens after "else if" to feed the condition
+			lineTokens := NewLineTokens(lines[currentLine])
+			lineTokens.Pop()
+			lineTokens.Pop()
+			ifElseCondition := lineTokens.Rest()
 
 			// Append the new condition block to the list
-			conditionBlocks = append(conditionBlocks, ConditionBlock{ifElseCondition, currentLine, ""})
+			conditionBlocks = append(conditionBlocks, ConditionBlock{lineTokens.Text(ifElseCondition), ifElseCondition.Start, currentLine, ""})
 			currentLine++ // Skip to next line to avoid including the condition ending in the code
 			continue
 
@@ -78,7 +76,7 @@ func ParseConditionBlocks(tokens *Queue, currentLine int, lines []string, startL
 			} else {
 
 				// Append the condition block to the list
-				conditionBlocks = append(conditionBlocks, ConditionBlock{"else", currentLine, ""})
+				conditionBlocks = append(conditionBlocks, ConditionBlock{"else", 0, currentLine, ""})
 				currentLine++
 				continue
 			}
@@ -119,7 +117,7 @@ func ParseConditionBlocks(tokens *Queue, currentLine int, lines []string, startL
 	// Check if the end of the block was found
 	// e.g. "end if"
 	if !foundBoundary {
-		return []ConditionBlock{}, currentLine, CreateError("Condition block not closed with \"end if\"", conditionBlocks[len(conditionBlocks)-1].ConditionIndex+1+startLine)
+		return []ConditionBlock{}, currentLine, unclosedBlockError("if", "if", lines, conditionBlocks[0].ConditionIndex, startLine)
 	}
 
 	// Return the condition blocks
@@ -0,0 +1,143 @@
+package kode
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+/**
+ * Read the next line of the input of the interpreter, without the line break.
+ * @return string - The line.
+ * @return bool - False if the end of the input was reached before reading anything.
+ * @return error - The error if the input cannot be read.
+ */
+func (interpreter *Interpreter) ReadLine() (string, bool, error) {
+	line, err := (*interpreter).Input.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", false, err
+	}
+	if err == io.EOF && line == "" {
+		return "", false, nil
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), true, nil
+}
+
+/**
+ * Display a prompt and read a line of the input. Null is returned at the end of the input.
+ * e.g. val name = input("What is your name? ")
+ * @param args :[]*Variable - The optional prompt.
+ * @return *Variable - The line.
+ * @return error - The error if one occurs.
+**/
+func Input(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) > 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "0 or 1", "input"), startLine)
+	}
+
+	interpreter := scope.GetInterpreter()
+	if len(args) == 1 {
+		fmt.Fprint((*interpreter).Output, FormatVariable(*args[0]))
+	}
+
+	return readLine("input", interpreter, startLine)
+}
+
+/**
+ * Read a line of the input. Null is returned at the end of the input.
+ * @param args :[]*Variable - No arguments.
+ * @return *Variable - The line.
+ * @return error - The error if one occurs.
+**/
+func ReadLine(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "0", "readLine"), startLine)
+	}
+
+	return readLine("readLine", scope.GetInterpreter(), startLine)
+}
+
+/**
+ * Read a line of the input as a variable.
+ * @param name : string - The name of the Kode function.
+ * @param interpreter : *Interpreter - The interpreter.
+ * @return *Variable - The line, or null at the end of the input.
+ * @return error - The error if one occurs.
+**/
+func readLine(name string, interpreter *Interpreter, startLine int) (*Variable, *ErrorStack) {
+	line, ok, err := interpreter.ReadLine()
+	if err != nil {
+		return NullVariable(), CreateError(ErrorMessage(MSG_READ_INPUT, err.Error(), name), startLine)
+	}
+	if !ok {
+		return NullVariable(), nil
+	}
+
+	variable := CreateVariable(line)
+	return &variable, nil
+}
+
+/**
+ * Read a line of the input as an int. Spaces around the number are ignored.
+ * @param args :[]*Variable - No arguments.
+ * @return *Variable - The int.
+ * @return error - The error if the line is not an int or if the end of the input was reached.
+**/
+func ReadInt(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "0", "readInt"), startLine)
+	}
+
+	line, ok, err := scope.GetInterpreter().ReadLine()
+	if err != nil {
+		return NullVariable(), CreateError(ErrorMessage(MSG_READ_INPUT, err.Error(), "readInt"), startLine)
+	}
+	if !ok {
+		return NullVariable(), CreateError(ErrorMessage(MSG_END_OF_INPUT, "readInt"), startLine)
+	}
+
+	i, parseErr := strconv.ParseInt(strings.TrimSpace(line), 10, 64)
+	if parseErr != nil {
+		return NullVariable(), CreateError(ErrorMessage(MSG_INVALID_INT, QuoteString(line), "readInt"), startLine)
+	}
+
+	variable := CreateVariable(i)
+	return &variable, nil
+}
+
+/**
+ * Read the rest of the input.
+ * @param args :[]*Variable - No arguments.
+ * @return *Variable - The rest of the input (empty at the end of the input).
+ * @return error - The error if one occurs.
+**/
+func ReadAll(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "0", "readAll"), startLine)
+	}
+
+	content, err := io.ReadAll(scope.GetInterpreter().Input)
+	if err != nil {
+		return NullVariable(), CreateError(ErrorMessage(MSG_READ_INPUT, err.Error(), "readAll"), startLine)
+	}
+
+	variable := CreateVariable(string(content))
+	return &variable, nil
+}
+
+/**
+ * Check if the end of the input was reached.
+ * @param args :[]*Variable - No arguments.
+ * @return *Variable - True if there is nothing left to read.
+ * @return error - The error if one occurs.
+**/
+func EOF(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "0", "eof"), startLine)
+	}
+
+	_, err := scope.GetInterpreter().Input.Peek(1)
+	variable := CreateVariable(err != nil)
+	return &variable, nil
+}
@@ -0,0 +1,63 @@
+package kode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+/**
+ * Run a program reading a given input.
+ * @param t : *testing.T - The test.
+ * @param code : string - The program.
+ * @param input : string - The input of the program.
+ * @return string - The output of the program.
+ * @return error - The error of the program, if any.
+ */
+func runInput(t *testing.T, code string, input string) (string, error) {
+	t.Helper()
+	interpreter := NewInterpreter()
+	output := bytes.Buffer{}
+	interpreter.Output = &output
+	interpreter.SetInput(strings.NewReader(input))
+	err := interpreter.Run(code)
+	return output.String(), err
+}
+
+func TestConsoleInput(t *testing.T) {
+	code := `val name = input("Name? ")
+int age = readInt()
+print(name, age, eof())
+print(readLine(), eof())
+print(readLine())`
+	expected := "Name? Ada 36 false\nlast true\nnull\n"
+	output, err := runInput(t, code, "Ada\r\n 36 \nlast\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output != expected {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+}
+
+func TestConsoleInputErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "", expected: E_INPUT},
+		{input: "12a\n", expected: E_INVALID_FORMAT},
+	}
+
+	for _, test := range tests {
+		_, err := runInput(t, "print(readInt())", test.input)
+		errorStack, ok := err.(*ErrorStack)
+		if !ok {
+			t.Errorf("%q: expected an error, got %v", test.input, err)
+			continue
+		}
+		if code := errorStack.Cause().ErrorCode(); code != test.expected {
+			t.Errorf("%q: expected %s, got %s", test.input, test.expected, code)
+		}
+	}
+}
@@ -0,0 +1,497 @@
+package kode
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Fields converted to numbers by the CSV functions (e.g. "42", "-1.5" or "3e8")
+var csvNumberFormat = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// ! CSVReader : A CSV source read one row at a time (e.g. "for row in openCSV(path)").
+// The file is closed once every row has been read, or when a loop over the reader stops.
+type CSVReader struct {
+	Source  string
+	reader  *csv.Reader
+	file    *os.File
+	header  []string
+	convert bool
+}
+
+/**
+ * Create a CSV reader and read the header row if there is one.
+ * @param source : string - The name of the source (e.g. the path of the file), used in the errors.
+ * @param input : io.Reader - The CSV content.
+ * @param file : *os.File - The file to close at the end, or nil.
+ * @param hasHeader : bool - True if the first row contains the names of the columns.
+ * @param convert : bool - True to convert the numeric fields to ints and floats.
+ * @return *CSVReader - The reader.
+ * @return error - The error if one occurs.
+ */
+func NewCSVReader(source string, input io.Reader, file *os.File, hasHeader bool, convert bool) (*CSVReader, error) {
+	reader := &CSVReader{
+		Source:  source,
+		reader:  csv.NewReader(input),
+		file:    file,
+		convert: convert,
+	}
+
+	if hasHeader {
+		header, err := reader.reader.Read()
+		if err != nil && err != io.EOF {
+			reader.Close()
+			return nil, err
+		}
+		reader.header = header
+		if header == nil {
+			// An empty source has no header and no rows
+			reader.header = []string{}
+		}
+	}
+
+	return reader, nil
+}
+
+/**
+ * Read the next row, either as an array of fields or as a record (an object keyed by the header). The columns whose
+ * names are not valid names (e.g. "e-mail") are read with "get".
+ * @return *Variable - The row, or nil once every row has been read.
+ * @return error - The error if one occurs.
+ */
+func (reader *CSVReader) Next() (*Variable, error) {
+	if reader.reader == nil {
+		return nil, nil
+	}
+
+	fields, err := reader.reader.Read()
+	if err == io.EOF {
+		reader.Close()
+		return nil, nil
+	}
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+
+	values := make([]Variable, len(fields))
+	for i, field := range fields {
+		values[i] = reader.convertField(field)
+	}
+
+	if reader.header == nil {
+		variable := CreateVariable(values)
+		return &variable, nil
+	}
+
+	record := map[string]*Variable{}
+	for i, name := range reader.header {
+		record[name] = &values[i]
+	}
+	variable := CreateOrderedObject("record", reader.header, record)
+	return &variable, nil
+}
+
+/**
+ * Read every remaining row.
+ * @return []Variable - The rows.
+ * @return error - The error if one occurs.
+ */
+func (reader *CSVReader) ReadAll() ([]Variable, error) {
+	rows := []Variable{}
+	for {
+		row, err := reader.Next()
+		if err != nil {
+			return nil, err
+		}
+		if row == nil {
+			return rows, nil
+		}
+		rows = append(rows, *row)
+	}
+}
+
+/**
+ * Stop reading and close the file. Closing a reader twice has no effect.
+ */
+func (reader *CSVReader) Close() {
+	if reader.file != nil {
+		reader.file.Close()
+		reader.file = nil
+	}
+	reader.reader = nil
+}
+
+/**
+ * Convert a field to an int or a float if the reader converts numbers and the field is numeric.
+ * @param field : string - The field.
+ * @return Variable - The field as a string, an int or a float.
+ */
+func (reader *CSVReader) convertField(field string) Variable {
+	if reader.convert && csvNumberFormat.MatchString(field) {
+		if !strings.ContainsAny(field, ".eE") {
+			if i, err := strconv.ParseInt(field, 10, 64); err == nil {
+				return CreateVariable(i)
+			}
+		}
+		if f, err := strconv.ParseFloat(field, 64); err == nil {
+			return CreateVariable(f)
+		}
+	}
+	return CreateVariable(field)
+}
+
+/**
+ * Get the options of the CSV reading functions: whether there is a header row and whether to convert numbers.
+ * @param name : string - The name of the Kode function.
+ * @param args :[]*Variable - The source followed by the optional header and convert flags.
+ * @return bool - True if the first row is a header.
+ * @return bool - True to convert the numeric fields.
+ * @return error - The error if one occurs.
+**/
+func csvReadOptions(name string, args []*Variable, startLine int) (bool, bool, *ErrorStack) {
+	if len(args) < 1 || len(args) > 3 {
+		return false, false, CreateError(ErrorMessage(MSG_EXPECTED_ARGS_RANGE, "1", "3", name), startLine)
+	}
+
+	if args[0].Type != "string" {
+		return false, false, CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "a string", name), startLine)
+	}
+
+	flags := []bool{false, false}
+	for i, arg := range args[1:] {
+		if arg.Type != "bool" {
+			return false, false, CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, strconv.Itoa(i+2), "a bool", name), startLine)
+		}
+		flags[i] = arg.Value.(bool)
+	}
+
+	return flags[0], flags[1], nil
+}
+
+/**
+ * Create the error of an invalid CSV source, with the line and column of the invalid field.
+ * @param name : string - The name of the Kode function.
+ * @param source : string - The name of the source.
+ * @param err : error - The error of the CSV reader.
+ * @return error - The Kode error.
+**/
+func csvError(name string, source string, err error, startLine int) *ErrorStack {
+	var parseError *csv.ParseError
+	if errors.As(err, &parseError) {
+		return CreateError(ErrorMessage(MSG_INVALID_CSV, source, strconv.Itoa(parseError.Line), strconv.Itoa(parseError.Column), parseError.Err.Error(), name), startLine)
+	}
+	return CreateError(ErrorMessage(MSG_FAILED, err.Error(), name), startLine)
+}
+
+/**
+ * Open a CSV file to read it row by row.
+ * @param name : string - The name of the Kode function.
+ * @param path : string - The path of the file.
+ * @return *CSVReader - The reader.
+ * @return error - The error if one occurs.
+**/
+func (scope *Function) openCSVFile(name string, path string, hasHeader bool, convert bool, startLine int) (*CSVReader, *ErrorStack) {
+	if err := scope.CheckPermission(name, path, false, startLine); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fileError(name, path, err, startLine)
+	}
+
+	reader, err := NewCSVReader(QuoteString(path), file, file, hasHeader, convert)
+	if err != nil {
+		return nil, csvError(name, QuoteString(path), err, startLine)
+	}
+	return reader, nil
+}
+
+/**
+ * Read a CSV file into an array of rows.
+ * e.g. readCSV("data.csv") returns arrays of fields, readCSV("data.csv", true, true) returns records
+ * keyed by the header with numeric fields converted to ints and floats.
+ * @param args :[]*Variable - The path, then optionally the header and convert flags.
+ * @return *Variable - The rows.
+ * @return error - The error if one occurs.
+**/
+func ReadCSV(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	hasHeader, convert, err := csvReadOptions("readCSV", args, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	reader, err := scope.openCSVFile("readCSV", args[0].Value.(string), hasHeader, convert, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	rows, readErr := reader.ReadAll()
+	if readErr != nil {
+		return NullVariable(), csvError("readCSV", reader.Source, readErr, startLine)
+	}
+
+	variable := CreateVariable(rows)
+	return &variable, nil
+}
+
+/**
+ * Parse a CSV string into an array of rows. The options are the same as "readCSV".
+ * @param args :[]*Variable - The CSV string, then optionally the header and convert flags.
+ * @return *Variable - The rows.
+ * @return error - The error if one occurs.
+**/
+func ParseCSV(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	hasHeader, convert, err := csvReadOptions("parseCSV", args, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	reader, readErr := NewCSVReader("string", strings.NewReader(args[0].Value.(string)), nil, hasHeader, convert)
+	if readErr != nil {
+		return NullVariable(), csvError("parseCSV", "string", readErr, startLine)
+	}
+
+	rows, readErr := reader.ReadAll()
+	if readErr != nil {
+		return NullVariable(), csvError("parseCSV", "string", readErr, startLine)
+	}
+
+	variable := CreateVariable(rows)
+	return &variable, nil
+}
+
+/**
+ * Open a CSV file to stream its rows, without loading the whole file.
+ * e.g. for row in openCSV("big.csv", true) ... end for
+ * @param args :[]*Variable - The path, then optionally the header and convert flags.
+ * @return *Variable - The CSV reader.
+ * @return error - The error if one occurs.
+**/
+func OpenCSV(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	hasHeader, convert, err := csvReadOptions("openCSV", args, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	reader, err := scope.openCSVFile("openCSV", args[0].Value.(string), hasHeader, convert, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	variable := CreateVariable(reader)
+	return &variable, nil
+}
+
+/**
+ * Format rows as CSV. Rows are arrays of values or records (objects). The header is written first if
+ * one is provided; for records without a header, the fields of the first record are used, in the order of the
+ * columns they were read from (e.g. with "readCSV").
+ * @param name : string - The name of the Kode function.
+ * @param rows : *Variable - The rows.
+ * @param header : *Variable - The names of the columns, or nil.
+ * @return string - The CSV content.
+ * @return error - The error if one occurs.
+**/
+func formatCSV(name string, rows *Variable, header *Variable, startLine int) (string, *ErrorStack) {
+	if !isArrayType(rows.Type) {
+		return "", CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "an array of rows", name), startLine)
+	}
+
+	columns := []string(nil)
+	if header != nil {
+		if header.Type != "string[]" && !(header.Type == "val[]" && len(header.Elements()) == 0) {
+			return "", CreateError(ErrorMessage(MSG_CSV_HEADER, name), startLine)
+		}
+		columns = []string{}
+		for _, column := range header.Elements() {
+			columns = append(columns, column.Value.(string))
+		}
+	}
+
+	builder := &strings.Builder{}
+	writer := csv.NewWriter(builder)
+	if columns != nil {
+		writer.Write(columns)
+	}
+
+	for i, row := range rows.Elements() {
+		fields := []string{}
+		switch value := row.Value.(type) {
+		case *Array:
+			for _, field := range value.Elements {
+				fields = append(fields, formatCSVField(field))
+			}
+		case Function:
+			if !IsInstance(value) {
+				return "", CreateError(ErrorMessage(MSG_CSV_ROW, strconv.Itoa(i), name), startLine)
+			}
+			if columns == nil {
+				columns = ObjectFields(value)
+				writer.Write(columns)
+			}
+			for _, column := range columns {
+				field, exists := value.Variables[column]
+				if !exists || field == nil {
+					fields = append(fields, "")
+				} else {
+					fields = append(fields, formatCSVField(*field))
+				}
+			}
+		default:
+			return "", CreateError(ErrorMessage(MSG_CSV_ROW, strconv.Itoa(i), name), startLine)
+		}
+		writer.Write(fields)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", CreateError(ErrorMessage(MSG_FAILED, err.Error(), name), startLine)
+	}
+	return builder.String(), nil
+}
+
+/**
+ * Format a single CSV field. Null values are written as empty fields.
+ * @param field : Variable - The value of the field.
+ * @return string - The formatted field (quoted by the CSV writer if needed).
+ */
+func formatCSVField(field Variable) string {
+	if field.Value == nil {
+		return ""
+	}
+	return FormatVariable(field)
+}
+
+/**
+ * Format rows as a CSV string. Fields containing commas, quotes or line breaks are quoted.
+ * e.g. toCSV([["name", "city"], ["Ada", "London, UK"]])
+ * @param args :[]*Variable - The rows, then optionally the header.
+ * @return *Variable - The CSV string.
+ * @return error - The error if one occurs.
+**/
+func ToCSV(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 && len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "1 or 2", "toCSV"), startLine)
+	}
+
+	var header *Variable
+	if len(args) == 2 {
+		header = args[1]
+	}
+
+	content, err := formatCSV("toCSV", args[0], header, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	variable := CreateVariable(content)
+	return &variable, nil
+}
+
+/**
+ * Write rows to a CSV file, replacing its content. The rows are formatted like "toCSV".
+ * @param args :[]*Variable - The path, the rows, then optionally the header.
+ * @return *Variable - Null.
+ * @return error - The error if one occurs.
+**/
+func WriteCSV(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 && len(args) != 3 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2 or 3", "writeCSV"), startLine)
+	}
+
+	if args[0].Type != "string" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "a string", "writeCSV"), startLine)
+	}
+
+	var header *Variable
+	if len(args) == 3 {
+		header = args[2]
+	}
+
+	content, err := formatCSV("writeCSV", args[1], header, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	path := args[0].Value.(string)
+	if err := scope.CheckPermission("writeCSV", path, true, startLine); err != nil {
+		return NullVariable(), err
+	}
+	if writeErr := os.WriteFile(path, []byte(content), 0644); writeErr != nil {
+		return NullVariable(), fileError("writeCSV", path, writeErr, startLine)
+	}
+
+	return NullVariable(), nil
+}
+
+/**
+ * Check that a method is called on a CSV reader.
+ * @param name : string - The name of the method.
+ * @param args :[]*Variable - The reader followed by the arguments of the method.
+ * @return *CSVReader - The reader.
+ * @return error - The error if one occurs.
+**/
+func checkCSVReaderArgs(name string, args []*Variable, startLine int) (*CSVReader, *ErrorStack) {
+	if len(args) != 1 {
+		return nil, CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "0", name), startLine)
+	}
+
+	if args[0].Type != "csv" {
+		return nil, CreateError(ErrorMessage(MSG_CALLED_ON, name, "a CSV reader"), startLine)
+	}
+
+	return args[0].Value.(*CSVReader), nil
+}
+
+/**
+ * Read the next row of a CSV reader, or null once every row has been read.
+**/
+func CSVNext(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	reader, err := checkCSVReaderArgs("next", args, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	row, readErr := reader.Next()
+	if readErr != nil {
+		return NullVariable(), csvError("next", reader.Source, readErr, startLine)
+	}
+	if row == nil {
+		return NullVariable(), nil
+	}
+	return row, nil
+}
+
+/**
+ * Get the header of a CSV reader, or null if it reads rows without a header.
+**/
+func CSVHeader(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	reader, err := checkCSVReaderArgs("header", args, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	if reader.header == nil {
+		return NullVariable(), nil
+	}
+	return createStringArray(reader.header), nil
+}
+
+/**
+ * Stop reading a CSV reader and close its file.
+**/
+func CSVClose(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	reader, err := checkCSVReaderArgs("close", args, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	reader.Close()
+	return NullVariable(), nil
+}
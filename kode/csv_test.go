@@ -0,0 +1,67 @@
+package kode
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestCSVColumnsAreNotNames(t *testing.T) {
+	code := `val rows = parseCSV("first name,e-mail\nAda,ada@example.com", true)
+print(get(rows[0], "first name"), get(rows[0], "e-mail"), has(rows[0], "e-mail"))`
+	expected := "Ada ada@example.com true\n"
+	if output := runOutput(t, code); output != expected {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+}
+
+func TestCSVReaderClosedOnEarlyExit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "people.csv")
+	if err := os.WriteFile(path, []byte("name\nAda\nBob\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A closed reader has no rows left, so the second loop prints nothing
+	tests := []struct {
+		name string
+		exit string
+	}{
+		{name: "break", exit: "break"},
+		{name: "error", exit: "print(1 / 0)"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code := "val r = openCSV(" + strconv.Quote(path) + `, true)
+try
+  for row in r
+    print(row.name)
+    ` + test.exit + `
+  end for
+catch e
+end try
+for row in r
+  print(row.name)
+end for`
+			if output := runOutput(t, code); output != "Ada\n" {
+				t.Errorf("expected %q, got %q", "Ada\n", output)
+			}
+		})
+	}
+
+	t.Run("return", func(t *testing.T) {
+		code := "val r = openCSV(" + strconv.Quote(path) + `, true)
+func first()
+  for row in r
+    return row.name
+  end for
+end first
+print(first())
+for row in r
+  print(row.name)
+end for`
+		if output := runOutput(t, code); output != "Ada\n" {
+			t.Errorf("expected %q, got %q", "Ada\n", output)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package kode
+
+import "errors"
+
+// ! Diagnostic : An error in a form that tools can read (e.g. as JSON for editors and CI).
+// -------------------------
+// ! Code : The code of the error (e.g. E0100).
+// -------------------------
+// ! Severity : How serious the diagnostic is ("error").
+// -------------------------
+// ! Kind : What caused the error: "runtime", "syntax" or "exit".
+// -------------------------
+// ! Message : The message of the cause, without the "Error: " prefix.
+// -------------------------
+// ! File, Line, Column, Length : The location of the cause (the length is the number of characters it spans).
+// -------------------------
+// ! Hint : A suggestion to fix the error.
+// -------------------------
+// ! Chain : The errors of the stack from the outer context to the cause.
+// -------------------------
+// ! Trace : The call stack of the cause, from the first call to the last one (see Frame).
+type Diagnostic struct {
+	Code     string            `json:"code"`
+	Severity string            `json:"severity"`
+	Kind     string            `json:"kind"`
+	Message  string            `json:"message"`
+	File     string            `json:"file,omitempty"`
+	Line     int               `json:"line"`
+	Column   int               `json:"column"`
+	Length   int               `json:"length,omitempty"`
+	Hint     string            `json:"hint,omitempty"`
+	Chain    []DiagnosticFrame `json:"chain"`
+	Trace    []Frame           `json:"trace,omitempty"`
+}
+
+// ! DiagnosticFrame : An error of the stack of a diagnostic.
+type DiagnosticFrame struct {
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+/**
+ * Get the diagnostic of the error stack.
+ * @param e *ErrorStack - The error stack.
+ * @return Diagnostic - The diagnostic of the cause, with the whole stack in its chain.
+ */
+func (e *ErrorStack) Diagnostic() Diagnostic {
+	cause := e.Cause()
+	diagnostic := Diagnostic{
+		Code:     cause.ErrorCode(),
+		Severity: "error",
+		Kind:     (*cause).Kind.String(),
+		Message:  trimErrorPrefix((*cause).Message),
+		File:     (*cause).File,
+		Line:     (*cause).Line,
+		Column:   (*cause).Column,
+		Length:   (*cause).Length,
+		Hint:     (*cause).Hint,
+		Chain:    []DiagnosticFrame{},
+		Trace:    (*cause).Trace,
+	}
+
+	for ; e != nil; e = (*e).NextError {
+		diagnostic.Chain = append(diagnostic.Chain, DiagnosticFrame{
+			Message: trimErrorPrefix((*e).Message),
+			File:    (*e).File,
+			Line:    (*e).Line,
+			Column:  (*e).Column,
+		})
+	}
+	return diagnostic
+}
+
+/**
+ * Get the diagnostic of any error. The errors that are not error stacks (e.g. errors of Go) are runtime errors
+ * without a location.
+ * @param err : error - The error.
+ * @return Diagnostic - The diagnostic of the error.
+ */
+func ErrorDiagnostic(err error) Diagnostic {
+	var errorStack *ErrorStack
+	if errors.As(err, &errorStack) {
+		return errorStack.Diagnostic()
+	}
+
+	message := trimErrorPrefix(err.Error())
+	return Diagnostic{
+		Code:     E_RUNTIME,
+		Severity: "error",
+		Kind:     RUNTIME_ERROR.String(),
+		Message:  message,
+		Chain:    []DiagnosticFrame{{Message: message}},
+	}
+}
+
+/**
+ * Get the name of the kind of an error.
+ * @return string - "runtime", "syntax" or "exit".
+ */
+func (kind ErrorKind) String() string {
+	switch kind {
+	case SYNTAX_ERROR:
+		return "syntax"
+	case EXIT:
+		return "exit"
+	}
+	return "runtime"
+}
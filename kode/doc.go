@@ -0,0 +1,82 @@
+package kode
+
+import "strings"
+
+// ! FunctionDoc : The documentation of a function declared in Kode code.
+// -------------------------
+// ! Name : The name of the function, prefixed by the enclosing functions (e.g. "Point.norm").
+// -------------------------
+// ! Signature : The declaration of the function (e.g. "func add(int a, int b) int").
+// -------------------------
+// ! Comment : The comment lines right above the declaration, without the "#".
+// -------------------------
+// ! Line : The line number of the declaration.
+type FunctionDoc struct {
+	Name      string
+	Signature string
+	Comment   string
+	Line      int
+}
+
+/**
+ * Get the documentation of the functions declared in Kode code.
+ * The comment of a function is made of the comment lines right above its declaration.
+ * @param code : string - The code.
+ * @return []FunctionDoc - The documentation of each function, in order of declaration.
+ */
+func ExtractDocs(code string) []FunctionDoc {
+	docs := []FunctionDoc{}
+	comment := []string{}
+	functions := []string{} // Names of the enclosing functions
+	blocks := []string{}    // Kinds of the open blocks
+	aliases := codeKeywords(code, "")
+	_, pragmaLine := keywordsPragma(code)
+
+	for i, line := range LineParse(strings.ReplaceAll(code, "\r", "")) {
+		trimmed := strings.TrimSpace(line)
+
+		// Comment lines are kept until the next declaration
+		if strings.HasPrefix(trimmed, "#") {
+			if (i > 0 || !strings.HasPrefix(trimmed, "#!")) && i+1 != pragmaLine {
+				comment = append(comment, strings.TrimSpace(strings.TrimPrefix(trimmed, "#")))
+			}
+			continue
+		}
+
+		tokens := LineTokens(translateKeywords(trimmed, aliases))
+		if len(tokens) > 0 {
+			switch tokens[0] {
+			case "func":
+				name := ""
+				if len(tokens) > 1 {
+					name = tokens[1]
+				}
+				fullName := strings.Join(append(append([]string{}, functions...), name), ".")
+				docs = append(docs, FunctionDoc{
+					Name:      fullName,
+					Signature: collapseSpaces(strings.SplitN(trimmed, "#", 2)[0]),
+					Comment:   strings.Join(comment, "\n"),
+					Line:      i + 1,
+				})
+				functions = append(functions, name)
+				blocks = append(blocks, "func")
+			case "if", "for", "try":
+				blocks = append(blocks, tokens[0])
+			case "end":
+				if len(blocks) > 0 {
+					if blocks[len(blocks)-1] == "func" && len(functions) > 0 {
+						functions = functions[:len(functions)-1]
+					}
+					blocks = blocks[:len(blocks)-1]
+				}
+			}
+		}
+
+		comment = []string{}
+	}
+
+	for i := range docs {
+		docs[i].Signature = strings.TrimSpace(docs[i].Signature)
+	}
+	return docs
+}
@@ -3,16 +3,247 @@ package kode
 import (
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
+// ! ErrorKind : What caused an error.
+type ErrorKind int
+
+const (
+	RUNTIME_ERROR ErrorKind = iota // Error while running a valid program
+	SYNTAX_ERROR                   // Invalid code (e.g. a block without "end")
+	EXIT                           // The program called "exit" (not an actual error)
+)
+
+// ! ErrorStack : An error and the context it occurred in.
+// -------------------------
+// ! Kind : The kind of the error, set on the cause (see Cause).
+// -------------------------
+// ! ExitCode : The exit code requested by "exit".
+// -------------------------
+// ! Column : The column of the error in its line, starting at 1 (0 if unknown).
+// -------------------------
+// ! File : The name of the file of the program (empty if the code does not come from a file).
+// -------------------------
+// ! Length : The number of characters of the code the error points at (at least 1 if the column is known).
+// -------------------------
+// ! Snippet : The line of code of the error, set when the error is located in the source (see Locate).
+// -------------------------
+// ! Hint : A suggestion to fix the error (e.g. Did you mean "count"?).
+// -------------------------
+// ! Trace : The call stack when the error occurred, from the first call to the last one (set on the cause,
+// nil if the error did not occur in a function call).
+// -------------------------
+// ! span : Where the code the error points at is in its line (e.g. the unknown variable), used to find the column.
+// -------------------------
+// ! text : The message of the catalogue of the error, shown in the language of the user (see Localize).
+// -------------------------
+// ! hint : The message of the catalogue of the hint.
+// -------------------------
+// ! language : The language the error is shown in (see Localize), English if empty.
 type ErrorStack struct {
 	Message   string
 	Line      int
 	NextError *ErrorStack
-	Index     int
+	Kind      ErrorKind
+	ExitCode  int
+	Column    int
+	Length    int
+	File      string
+	Snippet   string
+	Hint      string
+	Trace     []Frame
+	span      Span
+	text      Message
+	hint      Message
+	language  string
 }
 
-var MAX_ERROR_DEPTH = 5
+// Codes of the errors, shown with the message so that an error can be looked up
+const (
+	E_RUNTIME          = "E0001" // Runtime error without a more precise code
+	E_SYNTAX           = "E0002" // Syntax error without a more precise code
+	E_UNKNOWN_NAME     = "E0100" // Unknown variable, function or command
+	E_UNKNOWN_METHOD   = "E0101" // Unknown method for the type of a value
+	E_UNKNOWN_KEYWORDS = "E0102" // Unknown language of the keywords
+	E_INVALID_NAME     = "E0103" // Missing or invalid name of a variable, function or parameter
+	E_NAME_IN_USE      = "E0104" // Name already used by a variable or a function
+	E_UNCLOSED_BLOCK   = "E0200" // Block without its "end"
+	E_MISMATCHED_END   = "E0201" // "end" that does not close the current block
+	E_MISSING_VALUE    = "E0202" // Operator without its values
+	E_PARENTHESES      = "E0203" // Parentheses or brackets that are not balanced
+	E_UNCLOSED_STRING  = "E0204" // String without its closing quote
+	E_INVALID_SYNTAX   = "E0205" // Expression that cannot be read
+	E_TYPE_MISMATCH    = "E0300" // Value of the wrong type
+	E_ARGUMENT_COUNT   = "E0301" // Wrong number of arguments
+	E_INVALID_ARGUMENT = "E0302" // Argument out of the values accepted by a function
+	E_ASSERTION        = "E0400" // Failed assertion
+	E_DIVIDE_BY_ZERO   = "E0500" // Division or modulo by zero
+	E_OUT_OF_BOUNDS    = "E0501" // Index or range outside of an array or a string
+	E_READ_ONLY        = "E0502" // Change of a constant or frozen value
+	E_RECURSION_LIMIT  = "E0503" // Too many nested calls
+	E_INVALID_FORMAT   = "E0504" // Text that cannot be parsed (e.g. a number, JSON or a date)
+	E_DENIED           = "E0505" // Operation denied by the sandbox
+	E_INPUT            = "E0506" // Input that cannot be read
+	E_IO               = "E0600" // File or directory that cannot be read or written
+)
+
+// Code of the errors of each message of the catalogue (see MESSAGES), E_RUNTIME or E_SYNTAX for the others
+var ERROR_CODES = map[string]string{
+	MSG_UNKNOWN_NAME:         E_UNKNOWN_NAME,
+	MSG_UNKNOWN_VARIABLE:     E_UNKNOWN_NAME,
+	MSG_NOT_IN_FUNCTION:      E_UNKNOWN_NAME,
+	MSG_UNDEFINED:            E_UNKNOWN_NAME,
+	MSG_UNKNOWN_FIELD:        E_UNKNOWN_NAME,
+	MSG_UNKNOWN_COMMAND:      E_UNKNOWN_NAME,
+	MSG_UNKNOWN_METHOD:       E_UNKNOWN_METHOD,
+	MSG_UNKNOWN_KEYWORDS:     E_UNKNOWN_KEYWORDS,
+	MSG_VARIABLE_NAME:        E_INVALID_NAME,
+	MSG_FUNCTION_NAME:        E_INVALID_NAME,
+	MSG_PARAMETER_NAME:       E_INVALID_NAME,
+	MSG_MISSING_FUNCTION:     E_INVALID_NAME,
+	MSG_MISSING_NAME:         E_INVALID_NAME,
+	MSG_MISSING_NEW_NAME:     E_INVALID_NAME,
+	MSG_EXPECTED_PARAMETER:   E_INVALID_NAME,
+	MSG_VARIABLE_EXISTS:      E_NAME_IN_USE,
+	MSG_NAME_IN_USE:          E_NAME_IN_USE,
+	MSG_UNCLOSED_BLOCK:       E_UNCLOSED_BLOCK,
+	MSG_MISMATCHED_END:       E_MISMATCHED_END,
+	MSG_MISSING_END_NAME:     E_MISMATCHED_END,
+	MSG_UNEXPECTED_END:       E_MISMATCHED_END,
+	MSG_UNEXPECTED_BLOCK_END: E_MISMATCHED_END,
+	MSG_UNEXPECTED_ELSE:      E_MISMATCHED_END,
+	MSG_UNEXPECTED_CATCH:     E_MISMATCHED_END,
+	MSG_EXPECTED_CATCH:       E_MISMATCHED_END,
+	MSG_ONE_CATCH:            E_MISMATCHED_END,
+	MSG_BREAK_OUTSIDE:        E_MISMATCHED_END,
+	MSG_MISSING_CONDITION:    E_MISSING_VALUE,
+	MSG_MISSING_AFTER:        E_MISSING_VALUE,
+	MSG_MISSING_SIDE:         E_MISSING_VALUE,
+	MSG_MISSING_VALUE:        E_MISSING_VALUE,
+	MSG_MISSING_ASSIGN:       E_MISSING_VALUE,
+	MSG_EMPTY_VALUE:          E_MISSING_VALUE,
+	MSG_EMPTY_EXPRESSION:     E_MISSING_VALUE,
+	MSG_EMPTY_INDEX:          E_MISSING_VALUE,
+	MSG_UNEXPECTED:           E_PARENTHESES,
+	MSG_MISSING_OPENING:      E_PARENTHESES,
+	MSG_MISSING_CLOSING:      E_PARENTHESES,
+	MSG_MISSING_BRACKET:      E_PARENTHESES,
+	MSG_MISSING_PARENTHESES:  E_PARENTHESES,
+	MSG_MISSING_CALL_CLOSING: E_PARENTHESES,
+	MSG_EXPECTED_CLOSING:     E_PARENTHESES,
+	MSG_PARAMETERS_START:     E_PARENTHESES,
+	MSG_ARRAY_NOT_CLOSED:     E_PARENTHESES,
+	MSG_MISSING_QUOTE:        E_UNCLOSED_STRING,
+	MSG_UNCLOSED_STRING:      E_UNCLOSED_STRING,
+	MSG_UNEXPECTED_AFTER:     E_INVALID_SYNTAX,
+	MSG_INVALID_ASSIGNMENT:   E_INVALID_SYNTAX,
+	MSG_IMPROPER_DOT:         E_INVALID_SYNTAX,
+	MSG_NUMBER_FORMAT:        E_INVALID_SYNTAX,
+	MSG_INVALID_EXPRESSION:   E_INVALID_SYNTAX,
+	MSG_INVALID_OPERATOR:     E_INVALID_SYNTAX,
+	MSG_EXPECTED_TYPE:        E_INVALID_SYNTAX,
+	MSG_EXPECTED_COMMA:       E_INVALID_SYNTAX,
+	MSG_TYPE_MISMATCH:        E_TYPE_MISMATCH,
+	MSG_ASSIGNMENT_TYPE:      E_TYPE_MISMATCH,
+	MSG_ARGUMENT_TYPE:        E_TYPE_MISMATCH,
+	MSG_RETURN_TYPE:          E_TYPE_MISMATCH,
+	MSG_ELEMENT_TYPE:         E_TYPE_MISMATCH,
+	MSG_PARAMETER_TYPE:       E_TYPE_MISMATCH,
+	MSG_CONDITION_BOOL:       E_TYPE_MISMATCH,
+	MSG_CONDITION_TYPE:       E_TYPE_MISMATCH,
+	MSG_CANNOT_COMPARE:       E_TYPE_MISMATCH,
+	MSG_INVALID_OPERATION:    E_TYPE_MISMATCH,
+	MSG_INVALID_NEGATION:     E_TYPE_MISMATCH,
+	MSG_CANNOT_ADD:           E_TYPE_MISMATCH,
+	MSG_CANNOT_ITERATE:       E_TYPE_MISMATCH,
+	MSG_NOT_A_FUNCTION:       E_TYPE_MISMATCH,
+	MSG_NOT_AN_ARRAY:         E_TYPE_MISMATCH,
+	MSG_NOT_INDEXABLE:        E_TYPE_MISMATCH,
+	MSG_INDEX_TYPE:           E_TYPE_MISMATCH,
+	MSG_INDEX_INT:            E_TYPE_MISMATCH,
+	MSG_INDEXES_INT:          E_TYPE_MISMATCH,
+	MSG_EXPECTED_ARGUMENT:    E_ARGUMENT_COUNT,
+	MSG_EXPECTED_ARGS:        E_ARGUMENT_COUNT,
+	MSG_EXPECTED_STRING:      E_ARGUMENT_COUNT,
+	MSG_EXPECTED_ARGS_RANGE:  E_ARGUMENT_COUNT,
+	MSG_AT_LEAST_ARGUMENT:    E_ARGUMENT_COUNT,
+	MSG_AT_LEAST_ARGS:        E_ARGUMENT_COUNT,
+	MSG_TOO_MANY_ARGS:        E_ARGUMENT_COUNT,
+	MSG_ARGUMENT_MUST:        E_INVALID_ARGUMENT,
+	MSG_ARGUMENT_N_MUST:      E_INVALID_ARGUMENT,
+	MSG_ARGUMENTS_MUST:       E_INVALID_ARGUMENT,
+	MSG_CALLED_ON:            E_INVALID_ARGUMENT,
+	MSG_AT_LEAST_NUMBER:      E_INVALID_ARGUMENT,
+	MSG_BOUNDS:               E_INVALID_ARGUMENT,
+	MSG_BOUND_VALUES:         E_INVALID_ARGUMENT,
+	MSG_RANGE_TOO_LARGE:      E_INVALID_ARGUMENT,
+	MSG_INT_OVERFLOW:         E_INVALID_ARGUMENT,
+	MSG_SAMPLE_SIZE:          E_INVALID_ARGUMENT,
+	MSG_POSITIVE_DEVIATION:   E_INVALID_ARGUMENT,
+	MSG_POSITIVE_RATE:        E_INVALID_ARGUMENT,
+	MSG_POSITIVE_INDENT:      E_INVALID_ARGUMENT,
+	MSG_POSITIVE_DURATION:    E_INVALID_ARGUMENT,
+	MSG_ARRAY_DIMENSION:      E_INVALID_ARGUMENT,
+	MSG_ARRAY_SIZE:           E_INVALID_ARGUMENT,
+	MSG_SINGLE_CHARACTER:     E_INVALID_ARGUMENT,
+	MSG_CODE_POINT:           E_INVALID_ARGUMENT,
+	MSG_ZONE_STRING:          E_INVALID_ARGUMENT,
+	MSG_INVALID_ENV:          E_INVALID_ARGUMENT,
+	MSG_EXIT_CODE:            E_INVALID_ARGUMENT,
+	MSG_ASSERTION:            E_ASSERTION,
+	MSG_ASSERTION_MESSAGE:    E_ASSERTION,
+	MSG_ASSERT_EQUAL:         E_ASSERTION,
+	MSG_ASSERT_EQUAL_MSG:     E_ASSERTION,
+	MSG_DIVIDE_BY_ZERO:       E_DIVIDE_BY_ZERO,
+	MSG_MODULO_BY_ZERO:       E_DIVIDE_BY_ZERO,
+	MSG_INDEX_OUT:            E_OUT_OF_BOUNDS,
+	MSG_INVALID_RANGE:        E_OUT_OF_BOUNDS,
+	MSG_POP_EMPTY:            E_OUT_OF_BOUNDS,
+	MSG_CHOOSE_EMPTY:         E_OUT_OF_BOUNDS,
+	MSG_CONSTANT:             E_READ_ONLY,
+	MSG_FROZEN_VALUE:         E_READ_ONLY,
+	MSG_FROZEN_ARRAY:         E_READ_ONLY,
+	MSG_FROZEN_FIELD:         E_READ_ONLY,
+	MSG_RECURSION_LIMIT:      E_RECURSION_LIMIT,
+	MSG_NOT_A_NUMBER:         E_INVALID_FORMAT,
+	MSG_INVALID_INT:          E_INVALID_FORMAT,
+	MSG_INVALID_JSON:         E_INVALID_FORMAT,
+	MSG_INVALID_CSV:          E_INVALID_FORMAT,
+	MSG_CSV_HEADER:           E_INVALID_FORMAT,
+	MSG_CSV_ROW:              E_INVALID_FORMAT,
+	MSG_INVALID_REGEX:        E_INVALID_FORMAT,
+	MSG_INVALID_REGEX_AT:     E_INVALID_FORMAT,
+	MSG_INVALID_PATTERN:      E_INVALID_FORMAT,
+	MSG_UNKNOWN_ZONE:         E_INVALID_FORMAT,
+	MSG_INVALID_DATE:         E_INVALID_FORMAT,
+	MSG_INVALID_LAYOUT:       E_INVALID_FORMAT,
+	MSG_PARSE_TIME:           E_INVALID_FORMAT,
+	MSG_PARSE_LAYOUT:         E_INVALID_FORMAT,
+	MSG_DENIED_READ:          E_DENIED,
+	MSG_DENIED_WRITE:         E_DENIED,
+	MSG_DENIED_PATH:          E_DENIED,
+	MSG_DENIED_ENV:           E_DENIED,
+	MSG_READ_INPUT:           E_INPUT,
+	MSG_END_OF_INPUT:         E_INPUT,
+	MSG_READ_FILE:            E_IO,
+	MSG_READ_STANDARD_IN:     E_IO,
+	MSG_WRITE_FILE:           E_IO,
+	MSG_FIND_FILE:            E_IO,
+	MSG_LIST_DIRECTORY:       E_IO,
+	MSG_FILE_NOT_FOUND:       E_IO,
+	MSG_FILE_DENIED:          E_IO,
+	MSG_FILE_ACCESS:          E_IO,
+	MSG_FILE_ACCESS_REASON:   E_IO,
+}
+
+// ANSI escape codes of the colored errors
+const (
+	colorReset = "\033[0m"
+	colorError = "\033[1;31m"
+	colorFrame = "\033[1;34m"
+	colorHint  = "\033[36m"
+)
 
 /**
  * Print the error stack.
@@ -20,54 +251,404 @@ var MAX_ERROR_DEPTH = 5
  * @return string - The error stack as a string.
  */
 func (e *ErrorStack) Error() string {
+	return e.Render(false)
+}
+
+/**
+ * Get the next error of the stack, so that errors.Is and errors.As can inspect the whole stack.
+ * @param e *ErrorStack - The error stack.
+ * @return error - The next error, or nil for the cause.
+ */
+func (e *ErrorStack) Unwrap() error {
+	if e == nil || (*e).NextError == nil {
+		return nil
+	}
+	return (*e).NextError
+}
+
+/**
+ * Render the error stack: the errors from the outer context to the cause, then the line of code of the cause with
+ * a caret under the failing code, and a hint to fix it.
+ * @param e *ErrorStack - The error stack to render.
+ * @param color : bool - True to color the output with ANSI escape codes (e.g. for a terminal).
+ * @return string - The rendered error stack.
+ */
+func (e *ErrorStack) Render(color bool) string {
 
 	// Empty error stack.
 	if e == nil {
 		return ""
 	}
 
-	txt := e.Message + " on line " + strconv.Itoa(e.Line) + "."
+	paint := func(code string, txt string) string {
+		if !color {
+			return txt
+		}
+		return code + txt + colorReset
+	}
+
+	cause := e.Cause()
+	txt := ""
+	for i := 0; e != nil; i, e = i+1, (*e).NextError {
+		line := e.Summary()
+		if e == cause {
+			line = paint(colorError, cause.label()) + strings.TrimPrefix((*e).Message, cause.errorWord()) + e.locationSuffix()
+		}
+		if i > 0 {
+			line = "\n" + strings.Repeat("  ", i) + "└" + line
+		}
+		txt += line
+	}
+
+	// Show the code of the cause
+	if (*cause).Snippet != "" && (*cause).Column > 0 {
+		number := strconv.Itoa((*cause).Line)
+		margin := strings.Repeat(" ", len(number)+2)
+		snippet := strings.TrimRight((*cause).Snippet, " \t\r")
+
+		// Keep the tabs so that the caret stays under the code
+		padding := ""
+		for i, char := range []rune(snippet) {
+			if i >= (*cause).Column-1 {
+				break
+			}
+			if char == '\t' {
+				padding += "\t"
+			} else {
+				padding += " "
+			}
+		}
+
+		length := (*cause).Length
+		if length < 1 {
+			length = 1
+		}
+
+		txt += "\n" + paint(colorFrame, margin+"|")
+		txt += "\n" + paint(colorFrame, " "+number+" |") + " " + snippet
+		txt += "\n" + paint(colorFrame, margin+"|") + " " + padding + paint(colorError, strings.Repeat("^", length))
+	}
 
-	i := 1
-	for e.NextError != nil {
-		e = e.NextError
-		txt += "\n" + strings.Repeat("  ", i) + "└" + e.Message + " on line " + strconv.Itoa(e.Line) + "."
-		i++
+	if (*cause).Hint != "" {
+		txt += "\n" + paint(colorHint, strings.Repeat(" ", len(strconv.Itoa((*cause).Line))+2)+"= "+(*cause).Hint)
+	}
+
+	if len((*cause).Trace) > 0 {
+		txt += "\n" + renderCallStack((*cause).Trace, (*cause).language)
 	}
 	return txt
 }
 
+/**
+ * Get the label of the error with its code, e.g. Error[E0100].
+ * @param e *ErrorStack - The error.
+ * @return string - The label.
+ */
+func (e *ErrorStack) label() string {
+	return e.errorWord() + "[" + e.ErrorCode() + "]"
+}
+
+/**
+ * Get the word starting the messages of the errors in the language of the error, e.g. "Error".
+ * @param e *ErrorStack - The error.
+ * @return string - The word.
+ */
+func (e *ErrorStack) errorWord() string {
+	return strings.TrimRight(Text((*e).language, MSG_ERROR, ""), " :")
+}
+
+/**
+ * Get the code of the error: the code of its message (see ERROR_CODES), or else the default code of its kind.
+ * @param e *ErrorStack - The error.
+ * @return string - The code, e.g. E0100.
+ */
+func (e *ErrorStack) ErrorCode() string {
+	if code, found := ERROR_CODES[(*e).text.ID]; found {
+		return code
+	}
+	if (*e).Kind == SYNTAX_ERROR {
+		return E_SYNTAX
+	}
+	return E_RUNTIME
+}
+
+/**
+ * Set the hint of the error, if there is one.
+ * @param e *ErrorStack - The error.
+ * @param hint : Message - The suggestion to fix the error, or an empty message.
+ * @return *ErrorStack - The error.
+**/
+func (e *ErrorStack) WithHint(hint Message) *ErrorStack {
+	if hint.ID != "" {
+		(*e).Hint = hint.String()
+		(*e).hint = hint
+	}
+	return e
+}
+
+/**
+ * Get the location of the error in the program.
+ * @param e *ErrorStack - The error.
+ * @return string - The location, e.g. "on line 3, column 7" or "at main.kd:3:7" if the file is known, or an empty
+ * string if the error is not in a program (e.g. a file that cannot be read).
+ */
+func (e *ErrorStack) Location() string {
+	if (*e).Line <= 0 && (*e).File == "" {
+		return ""
+	}
+	return location((*e).File, (*e).Line, (*e).Column, (*e).language)
+}
+
+/**
+ * Get the message of the error followed by its location, e.g. Error: Cannot divide by zero on line 3.
+ * @param e *ErrorStack - The error.
+ * @return string - The message and the location.
+ */
+func (e *ErrorStack) Summary() string {
+	return (*e).Message + e.locationSuffix()
+}
+
+/**
+ * Get the end of the message of the error: its location, if it has one, and a period.
+ * @param e *ErrorStack - The error.
+ * @return string - The end of the message, e.g. " on line 3.".
+ */
+func (e *ErrorStack) locationSuffix() string {
+	if where := e.Location(); where != "" {
+		return " " + where + "."
+	}
+	return "."
+}
+
+/**
+ * Get a location in a program, e.g. "on line 3, column 7" or "at main.kd:3:7" if the file is known.
+ * @param file : string - The name of the file (empty if unknown).
+ * @param line : int - The line.
+ * @param column : int - The column (0 if unknown).
+ * @param language : string - The language of the location (see LANGUAGES).
+ * @return string - The location.
+ */
+func location(file string, line int, column int, language string) string {
+	if file != "" {
+		position := file
+		if line > 0 {
+			position += ":" + strconv.Itoa(line)
+		}
+		if line > 0 && column > 0 {
+			position += ":" + strconv.Itoa(column)
+		}
+		return Text(language, MSG_AT, position)
+	}
+
+	location := Text(language, MSG_ON_LINE, strconv.Itoa(line))
+	if column > 0 {
+		location += Text(language, MSG_COLUMN, strconv.Itoa(column))
+	}
+	return location
+}
+
+// ! SyntaxErrors : The syntax errors found in a program before running it (see CheckSyntax).
+type SyntaxErrors struct {
+	Errors []*ErrorStack
+}
+
+/**
+ * Print the syntax errors, one after the other.
+ * @return string - The errors as a string.
+ */
+func (e *SyntaxErrors) Error() string {
+	errors := []string{}
+	for _, err := range (*e).Errors {
+		errors = append(errors, err.Error())
+	}
+	return strings.Join(errors, "\n")
+}
+
+/**
+ * Get the syntax errors, so that errors.As can get an *ErrorStack (the first one).
+ * @return []error - The errors.
+ */
+func (e *SyntaxErrors) Unwrap() []error {
+	errors := []error{}
+	for _, err := range (*e).Errors {
+		errors = append(errors, err)
+	}
+	return errors
+}
+
 /**
  * Create a new error stack.
- * @param message : string - The error message.
+ * @param message : Message - The error message (e.g. ErrorMessage(MSG_DIVIDE_BY_ZERO)).
  * @param line : int - The line number where the error occurred.
  * @return *ErrorStack - The new error stack.
 **/
-func CreateError(message string, line int) *ErrorStack {
-	return &ErrorStack{message, line, nil, 0}
+func CreateError(message Message, line int) *ErrorStack {
+	return &ErrorStack{Message: message.String(), Line: line, text: message}
 }
 
 /**
- * Add a new error to the error stack.
+ * Create a new error stack for invalid code.
+ * @param message : Message - The error message.
+ * @param line : int - The line number where the error occurred.
+ * @return *ErrorStack - The new error stack.
+**/
+func CreateSyntaxError(message Message, line int) *ErrorStack {
+	return &ErrorStack{Message: message.String(), Line: line, Kind: SYNTAX_ERROR, text: message}
+}
+
+/**
+ * Create a new error stack pointing at a piece of code (e.g. the name of an unknown variable).
+ * @param message : Message - The error message.
+ * @param span : Span - Where the code the error points at is in its line.
+ * @param line : int - The line number where the error occurred.
+ * @return *ErrorStack - The new error stack.
+**/
+func CreateTokenError(message Message, span Span, line int) *ErrorStack {
+	return &ErrorStack{Message: message.String(), Line: line, span: span, text: message}
+}
+
+/**
+ * Point the error at a piece of code if it does not point at anything yet.
+ * @param e *ErrorStack - The error.
+ * @param span : Span - Where the code the error points at is in its line.
+ * @return *ErrorStack - The error.
+**/
+func (e *ErrorStack) At(span Span) *ErrorStack {
+	if (*e).span.IsEmpty() && (*e).Column == 0 {
+		(*e).span = span
+	}
+	return e
+}
+
+/**
+ * Find the columns of the errors of the stack in the source of the program. An error points at its code if it is
+ * known, or else at the first character of its line.
  * @param e *ErrorStack - The error stack.
- * @param err : *ErrorStack - The error to add.
+ * @param lines : []string - The lines of the program.
+ * @param aliases : map[string]string - The aliases of the keywords the program is written with (see KEYWORD_ALIASES),
+ * since the spans of the errors are in the lines with English keywords.
 **/
-func (e *ErrorStack) AddError(err *ErrorStack) *ErrorStack {
+func (e *ErrorStack) Locate(lines []string, aliases map[string]string) {
+	for ; e != nil; e = (*e).NextError {
+		if (*e).Column > 0 || (*e).Line < 1 || (*e).Line > len(lines) {
+			continue
+		}
+		(*e).Snippet = lines[(*e).Line-1]
+		(*e).Column, (*e).Length = spanColumn((*e).Snippet, (*e).span, aliases)
+
+		// Point the calls at the name of their function
+		for i, frame := range (*e).Trace {
+			if frame.Column == 0 && frame.Line > 0 && frame.Line <= len(lines) {
+				(*e).Trace[i].Column, _ = spanColumn(lines[frame.Line-1], frame.span, aliases)
+			}
+		}
+	}
+}
 
-	if (*e).Index == MAX_ERROR_DEPTH {
-		// (*err).NextError = CreateError("(...)", 0)
-		// return e
-		new_err := CreateError("(...)", 0)
-		new_err.NextError = e
-		new_err.Index = MAX_ERROR_DEPTH + 1
-		return new_err
+/**
+ * Set the file of the errors of the stack that do not have one.
+ * @param e *ErrorStack - The error stack.
+ * @param file : string - The name of the file.
+**/
+func (e *ErrorStack) SetFile(file string) {
+	for ; e != nil; e = (*e).NextError {
+		if (*e).File == "" && (*e).Line > 0 {
+			(*e).File = file
+		}
+		for i, frame := range (*e).Trace {
+			if frame.File == "" && frame.Line > 0 {
+				(*e).Trace[i].File = file
+			}
+		}
 	}
+}
 
-	if (*e).Index > MAX_ERROR_DEPTH {
+/**
+ * Get the column of a piece of code in a line.
+ * @param line : string - The line.
+ * @param span : Span - Where the code is in the line with English keywords (empty for the start of the line).
+ * @param aliases : map[string]string - The aliases of the keywords of the line (see KEYWORD_ALIASES).
+ * @return int - The column starting at 1, counted in characters.
+ * @return int - The number of characters of the code (1 for the start of the line).
+ */
+func spanColumn(line string, span Span, aliases map[string]string) (int, int) {
+	translated, offsets := translateKeywordOffsets(line, aliases)
+	if span.IsEmpty() || span.End > len(translated) {
+		start := len(line) - len(strings.TrimLeft(line, " \t\r"))
+		return utf8.RuneCountInString(line[:start]) + 1, 1
+	}
+
+	start, end := offsets[span.Start], offsets[span.End]
+	length := utf8.RuneCountInString(line[start:end])
+	if length < 1 {
+		length = 1
+	}
+	return utf8.RuneCountInString(line[:start]) + 1, length
+}
+
+/**
+ * Get a function telling if a byte of a line is inside a string or a comment.
+ * @param line : string - The line.
+ * @return func(int) bool - True if the byte at the offset is inside a string (or is one of its quotes) or a comment.
+ */
+func stringRanges(line string) func(int) bool {
+	inside := make([]bool, len(line))
+	isInString := false
+	for i := 0; i < len(line); i++ {
+		if !isInString && line[i] == '#' {
+			for ; i < len(line); i++ {
+				inside[i] = true
+			}
+			break
+		}
+		if line[i] == '"' {
+			inside[i] = true
+			isInString = !isInString
+		} else if isInString {
+			inside[i] = true
+			if line[i] == '\\' && i+1 < len(line) {
+				i++
+				inside[i] = true
+			}
+		}
+	}
+	return func(offset int) bool {
+		return offset < len(inside) && inside[offset]
+	}
+}
+
+/**
+ * Check if a byte can be part of a name.
+ * @param char : byte - The byte.
+ * @return bool - True for letters, digits and underscores.
+ */
+func isWordByte(char byte) bool {
+	return char == '_' || char >= '0' && char <= '9' || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= 0x80
+}
+
+/**
+ * Get the error that caused the error stack (the last error of the stack).
+ * @param e *ErrorStack - The error stack.
+ * @return *ErrorStack - The cause.
+**/
+func (e *ErrorStack) Cause() *ErrorStack {
+	for (*e).NextError != nil {
+		e = (*e).NextError
+	}
+	return e
+}
+
+/**
+ * Add a new error to the error stack, giving the context of the error (e.g. the function it occurred in).
+ * Once an error leaves a function call, its call stack gives the context, so the error stack stops growing.
+ * @param e *ErrorStack - The error stack.
+ * @param err : *ErrorStack - The error to add, or nil if there is no context to add.
+**/
+func (e *ErrorStack) AddError(err *ErrorStack) *ErrorStack {
+	if err == nil || e.Cause().Trace != nil {
 		return e
 	}
 
 	(*err).NextError = e
-	(*err).Index = (*e).Index + 1
 	return err
 }
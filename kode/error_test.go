@@ -0,0 +1,224 @@
+package kode
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+/**
+ * Run a program that fails and get the cause of its error.
+ * @param t : *testing.T - The test.
+ * @param code : string - The program.
+ * @return *ErrorStack - The cause of the error.
+ */
+func runError(t *testing.T, code string) *ErrorStack {
+	t.Helper()
+	err := NewInterpreter().Run(code)
+	if err == nil {
+		t.Fatalf("expected an error for %q", code)
+	}
+	if syntaxErrors, ok := err.(*SyntaxErrors); ok {
+		return (*syntaxErrors).Errors[0].Cause()
+	}
+	return err.(*ErrorStack).Cause()
+}
+
+func TestErrorCodesHaveMessages(t *testing.T) {
+	for id := range ERROR_CODES {
+		if _, found := MESSAGES[id]; !found {
+			t.Errorf("the code of %q has no message", id)
+		}
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{code: `print(count)`, expected: E_UNKNOWN_NAME},
+		{code: "if true\nprint(1)", expected: E_UNCLOSED_BLOCK},
+		{code: `val x = (1 + 2`, expected: E_PARENTHESES},
+		{code: `int x = "a"`, expected: E_TYPE_MISMATCH},
+		{code: `len()`, expected: E_ARGUMENT_COUNT},
+		{code: `sqrt(-1)`, expected: E_INVALID_ARGUMENT},
+		{code: `assert(false)`, expected: E_ASSERTION},
+		{code: `print(1 / 0)`, expected: E_DIVIDE_BY_ZERO},
+		{code: "int[] a = [1]\nprint(a[3])", expected: E_OUT_OF_BOUNDS},
+		{code: "const int k = 1\nk = 2", expected: E_READ_ONLY},
+		{code: `parseJSON("{")`, expected: E_INVALID_FORMAT},
+		{code: `readFile("missing.txt")`, expected: E_IO},
+	}
+
+	for _, test := range tests {
+		if code := runError(t, test.code).ErrorCode(); code != test.expected {
+			t.Errorf("%q: expected %s, got %s", test.code, test.expected, code)
+		}
+	}
+}
+
+func TestErrorDiagnostic(t *testing.T) {
+	diagnostic := ErrorDiagnostic(CreateError(ErrorMessage(MSG_READ_FILE, "main.kd"), 0))
+	if diagnostic.Code != E_IO || diagnostic.Message != `Could not find and read the file "main.kd"` || len(diagnostic.Chain) != 1 {
+		t.Errorf("unexpected diagnostic of a file error: %+v", diagnostic)
+	}
+
+	diagnostic = ErrorDiagnostic(errors.New("Error: failed"))
+	if diagnostic.Code != E_RUNTIME || diagnostic.Kind != "runtime" || diagnostic.Message != "failed" || len(diagnostic.Chain) != 1 {
+		t.Errorf("unexpected diagnostic of a Go error: %+v", diagnostic)
+	}
+}
+
+func TestErrorLocations(t *testing.T) {
+	tests := []struct {
+		code   string
+		line   int
+		column int
+		length int
+	}{
+		// Index errors point at the indexed value
+		{code: "val a = [1]\nprint(a[3])", line: 2, column: 7, length: 4},
+		{code: "val arr = [1, 2, 3]\nval x = arr[1] + arr[9]", line: 2, column: 18, length: 6},
+
+		// A repeated token is found where it is, not at its first occurrence
+		{code: "val a = 1\nval b = a + a / 0", line: 2, column: 15, length: 1},
+		{code: "val s = \"x\"\nval y = s[0] + s[5]", line: 2, column: 16, length: 4},
+
+		// Nested blocks and function bodies
+		{code: "func f(int n)\n  if n > 0\n    for n > 0\n      val z = [1, 2]\n      print(z[5])\n    end for\n  end if\nend f\nf(1)", line: 5, column: 13, length: 4},
+		{code: "val x = 1\nif x > 5\n  print(1)\nelse if x + \"a\" > 2 # comment\n  print(2)\nend if", line: 4, column: 11, length: 1},
+		{code: "for e in 5\nend for", line: 1, column: 10, length: 1},
+		{code: "try\n  print(1 / 0)\ncatch e\n  print(e.nope)\nend try", line: 4, column: 11, length: 4},
+
+		// Assignments to constants and frozen values point at the whole statement
+		{code: "const k = 1\nk = 2", line: 2, column: 1, length: 5},
+		{code: "const int[] r = [1]\nr[0] = 5", line: 2, column: 1, length: 8},
+
+		// Translated keywords are mapped back to the code as written
+		{code: "#pragma keywords fr\nsi vrai et q\nfin si", line: 2, column: 12, length: 1},
+	}
+
+	for _, test := range tests {
+		interpreter := NewInterpreter()
+		interpreter.File = "main.kd"
+		interpreter.Output = &bytes.Buffer{}
+		err := interpreter.Run(test.code)
+		if err == nil {
+			t.Errorf("expected an error for %q", test.code)
+			continue
+		}
+		if syntaxErrors, ok := err.(*SyntaxErrors); ok {
+			err = (*syntaxErrors).Errors[0]
+		}
+		cause := err.(*ErrorStack).Cause()
+		if (*cause).File != "main.kd" || (*cause).Line != test.line || (*cause).Column != test.column || (*cause).Length != test.length {
+			t.Errorf("%q: expected main.kd:%d:%d (%d characters), got %s:%d:%d (%d characters)", test.code, test.line, test.column, test.length, (*cause).File, (*cause).Line, (*cause).Column, (*cause).Length)
+		}
+	}
+}
+
+func TestCallStackLocations(t *testing.T) {
+	cause := runError(t, "func f(int n)\n  return 1 + f(n + 1)\nend f\nval x = 2 + f(0)")
+	trace := (*cause).Trace
+	if len(trace) < 2 || trace[0].Line != 4 || trace[0].Column != 13 || trace[1].Line != 2 || trace[1].Column != 14 {
+		t.Errorf("unexpected call stack: %+v", trace)
+	}
+	if (*cause).Line != 2 || (*cause).Column != 14 {
+		t.Errorf("expected the recursion limit at 2:14, got %d:%d", (*cause).Line, (*cause).Column)
+	}
+}
+
+func TestSyntaxErrorsAreAllReported(t *testing.T) {
+	code := `print("start")
+if true
+  print(1)
+end fi
+val x = (1 + 2
+foo bar
+if false`
+	expected := []struct {
+		code string
+		line int
+	}{
+		{code: E_MISMATCHED_END, line: 4},
+		{code: E_PARENTHESES, line: 5},
+		{code: E_UNKNOWN_NAME, line: 6},
+		{code: E_UNCLOSED_BLOCK, line: 7},
+	}
+
+	interpreter := NewInterpreter()
+	output := bytes.Buffer{}
+	interpreter.Output = &output
+	err := interpreter.Run(code)
+
+	syntaxErrors, ok := err.(*SyntaxErrors)
+	if !ok {
+		t.Fatalf("expected syntax errors, got %v", err)
+	}
+	if len((*syntaxErrors).Errors) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %v", len(expected), len((*syntaxErrors).Errors), err)
+	}
+	for i, err := range (*syntaxErrors).Errors {
+		cause := err.Cause()
+		if cause.ErrorCode() != expected[i].code || (*cause).Line != expected[i].line {
+			t.Errorf("error %d: expected %s on line %d, got %s on line %d", i+1, expected[i].code, expected[i].line, cause.ErrorCode(), (*cause).Line)
+		}
+	}
+
+	// The program does not run at all
+	if output.Len() != 0 {
+		t.Errorf("expected no output, got %q", output.String())
+	}
+}
+
+func TestSessionErrorLocations(t *testing.T) {
+	// The errors of the functions declared by a previous run keep their column (e.g. the tests of "kode test")
+	interpreter := NewInterpreter()
+	interpreter.File = "main_test.kd"
+	session := interpreter.NewSession()
+	if _, err := session.Eval("func testDivide()\n  val x = 1\n  print(x / 0)\nend testDivide"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := session.Eval("testDivide()")
+	errorStack, ok := err.(*ErrorStack)
+	if !ok {
+		t.Fatalf("expected an error, got %v", err)
+	}
+	if cause := errorStack.Cause(); (*cause).Line != 3 || (*cause).Column != 11 {
+		t.Errorf("expected the error at 3:11, got %d:%d", (*cause).Line, (*cause).Column)
+	}
+}
+
+func TestErrorContext(t *testing.T) {
+	// The main scope and the blocks add no context, the functions do
+	tests := []struct {
+		code    string
+		context []string
+	}{
+		{code: "print(1 / 0)", context: []string{}},
+		{code: "val x = 1\nx / 0", context: []string{}},
+		{code: "if true\n  for i in [1]\n    print(i / 0)\n  end for\nend if", context: []string{}},
+		{code: "func f()\n  if true\n    print(1 / 0)\n  end if\nend f\nf()", context: []string{`In function "f"`}},
+	}
+
+	for _, test := range tests {
+		interpreter := NewInterpreter()
+		interpreter.Output = &bytes.Buffer{}
+		err, ok := interpreter.Run(test.code).(*ErrorStack)
+		if !ok {
+			t.Errorf("%q: expected an error", test.code)
+			continue
+		}
+
+		context := []string{}
+		for ; err.NextError != nil; err = err.NextError {
+			context = append(context, (*err).Message)
+		}
+		if strings.Join(context, "\n") != strings.Join(test.context, "\n") {
+			t.Errorf("%q: expected the context %q, got %q", test.code, test.context, context)
+		}
+	}
+}
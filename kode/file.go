@@ -0,0 +1,369 @@
+package kode
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+/**
+ * Check that the program is allowed to access a path.
+ * @param name : string - The name of the Kode function.
+ * @param path : string - The path to access.
+ * @param write : bool - True if the path is modified, false if it is only read.
+ * @param startLine : int - The line of the call.
+ * @return error - The error if the access is denied.
+**/
+func (scope *Function) CheckPermission(name string, path string, write bool, startLine int) *ErrorStack {
+	permissions := scope.GetInterpreter().Permissions
+
+	if write && !permissions.Write {
+		return CreateError(ErrorMessage(MSG_DENIED_WRITE, path, name), startLine)
+	}
+	if !write && !permissions.Read {
+		return CreateError(ErrorMessage(MSG_DENIED_READ, path, name), startLine)
+	}
+
+	if len(permissions.Paths) == 0 {
+		return nil
+	}
+
+	resolved := resolvePath(path)
+	for _, allowed := range permissions.Paths {
+		relative, err := filepath.Rel(resolvePath(allowed), resolved)
+		if err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			return nil
+		}
+	}
+	return CreateError(ErrorMessage(MSG_DENIED_PATH, path, name), startLine)
+}
+
+/**
+ * Get the absolute path of a file with the symbolic links resolved, so that a link cannot escape the allowed paths.
+ * A path that does not exist yet is resolved from its directory.
+ * @param path : string - The path.
+ * @return string - The resolved path.
+ */
+func resolvePath(path string) string {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(absolute); err == nil {
+		return resolved
+	}
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(absolute)); err == nil {
+		return filepath.Join(dir, filepath.Base(absolute))
+	}
+	return absolute
+}
+
+/**
+ * Check the path argument of a file system function and the permission to access it.
+ * @param name : string - The name of the Kode function.
+ * @param args :[]*Variable - The path followed by the other arguments.
+ * @param count : int - The expected number of arguments.
+ * @param write : bool - True if the path is modified.
+ * @return string - The path.
+ * @return error - The error if one occurs.
+**/
+func (scope *Function) checkPathArgs(name string, args []*Variable, count int, write bool, startLine int) (string, *ErrorStack) {
+	if err := checkStringArgs(name, args, count, count, startLine); err != nil {
+		return "", err
+	}
+
+	path := args[0].Value.(string)
+	if err := scope.CheckPermission(name, path, write, startLine); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+/**
+ * Create the error of a failed file system operation.
+ * @param name : string - The name of the Kode function.
+ * @param path : string - The path of the file.
+ * @param err : error - The error of the operation.
+ * @return error - The Kode error, e.g. Error: No such file or directory "data.txt" for "readFile".
+**/
+func fileError(name string, path string, err error, startLine int) *ErrorStack {
+	message := ErrorMessage(MSG_FILE_ACCESS, path, name)
+	switch {
+	case os.IsNotExist(err):
+		message = ErrorMessage(MSG_FILE_NOT_FOUND, path, name)
+	case os.IsPermission(err):
+		message = ErrorMessage(MSG_FILE_DENIED, path, name)
+	case err != nil:
+		// Keep the reason without the path (e.g. "is a directory")
+		if pathError, ok := err.(*os.PathError); ok {
+			message = ErrorMessage(MSG_FILE_ACCESS_REASON, pathError.Err.Error(), path, name)
+		}
+	}
+	return CreateError(message, startLine)
+}
+
+/**
+ * Read the content of a file as a string.
+ * @param args :[]*Variable - The path of the file.
+ * @return *Variable - The content of the file.
+ * @return error - The error if one occurs.
+**/
+func ReadFile(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	path, err := scope.checkPathArgs("readFile", args, 1, false, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	content, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return NullVariable(), fileError("readFile", path, readErr, startLine)
+	}
+
+	variable := CreateVariable(string(content))
+	return &variable, nil
+}
+
+/**
+ * Read the lines of a file. Line breaks ("\n" or "\r\n") are removed.
+ * @param args :[]*Variable - The path of the file.
+ * @return *Variable - The lines of the file.
+ * @return error - The error if one occurs.
+**/
+func ReadLines(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	path, err := scope.checkPathArgs("readLines", args, 1, false, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	content, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return NullVariable(), fileError("readLines", path, readErr, startLine)
+	}
+
+	text := strings.TrimSuffix(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	if text == "" {
+		return createStringArray([]string{}), nil
+	}
+	return createStringArray(strings.Split(text, "\n")), nil
+}
+
+/**
+ * Write a string to a file, replacing its content. The file is created if it does not exist.
+ * @param args :[]*Variable - The path of the file and the content.
+ * @return *Variable - Null.
+ * @return error - The error if one occurs.
+**/
+func WriteFile(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	path, err := scope.checkPathArgs("writeFile", args, 2, true, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	if writeErr := os.WriteFile(path, []byte(args[1].Value.(string)), 0644); writeErr != nil {
+		return NullVariable(), fileError("writeFile", path, writeErr, startLine)
+	}
+	return NullVariable(), nil
+}
+
+/**
+ * Add a string at the end of a file. The file is created if it does not exist.
+ * @param args :[]*Variable - The path of the file and the content.
+ * @return *Variable - Null.
+ * @return error - The error if one occurs.
+**/
+func AppendFile(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	path, err := scope.checkPathArgs("appendFile", args, 2, true, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	file, openErr := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if openErr != nil {
+		return NullVariable(), fileError("appendFile", path, openErr, startLine)
+	}
+	defer file.Close()
+
+	if _, writeErr := file.WriteString(args[1].Value.(string)); writeErr != nil {
+		return NullVariable(), fileError("appendFile", path, writeErr, startLine)
+	}
+	return NullVariable(), nil
+}
+
+/**
+ * Check if a file or a directory exists.
+ * @param args :[]*Variable - The path.
+ * @return *Variable - True if the path exists.
+ * @return error - The error if one occurs.
+**/
+func Exists(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	path, err := scope.checkPathArgs("exists", args, 1, false, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	_, statErr := os.Stat(path)
+	variable := CreateVariable(statErr == nil)
+	return &variable, nil
+}
+
+/**
+ * List the names of the entries of a directory, sorted by name.
+ * @param args :[]*Variable - The path of the directory.
+ * @return *Variable - The names of the files and directories.
+ * @return error - The error if one occurs.
+**/
+func ListDir(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	path, err := scope.checkPathArgs("listDir", args, 1, false, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	entries, readErr := os.ReadDir(path)
+	if readErr != nil {
+		return NullVariable(), fileError("listDir", path, readErr, startLine)
+	}
+
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	sort.Strings(names)
+	return createStringArray(names), nil
+}
+
+/**
+ * Create a directory and its missing parents. Nothing happens if the directory already exists.
+ * @param args :[]*Variable - The path of the directory.
+ * @return *Variable - Null.
+ * @return error - The error if one occurs.
+**/
+func Mkdir(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	path, err := scope.checkPathArgs("mkdir", args, 1, true, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	if mkdirErr := os.MkdirAll(path, 0755); mkdirErr != nil {
+		return NullVariable(), fileError("mkdir", path, mkdirErr, startLine)
+	}
+	return NullVariable(), nil
+}
+
+/**
+ * Remove a file or an empty directory.
+ * @param args :[]*Variable - The path.
+ * @return *Variable - Null.
+ * @return error - The error if one occurs.
+**/
+func Remove(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	path, err := scope.checkPathArgs("remove", args, 1, true, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	if removeErr := os.Remove(path); removeErr != nil {
+		return NullVariable(), fileError("remove", path, removeErr, startLine)
+	}
+	return NullVariable(), nil
+}
+
+/**
+ * Get information about a file or a directory.
+ * @param args :[]*Variable - The path.
+ * @return *Variable - An object with the name, the size in bytes, the modification time (in seconds since 1970) and isDir.
+ * @return error - The error if one occurs.
+**/
+func Stat(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	path, err := scope.checkPathArgs("stat", args, 1, false, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	info, statErr := os.Stat(path)
+	if statErr != nil {
+		return NullVariable(), fileError("stat", path, statErr, startLine)
+	}
+
+	name := CreateVariable(info.Name())
+	size := CreateVariable(info.Size())
+	modtime := CreateVariable(info.ModTime().Unix())
+	isDir := CreateVariable(info.IsDir())
+	variable := CreateObject("stat", map[string]*Variable{"name": &name, "size": &size, "modtime": &modtime, "isDir": &isDir})
+	return &variable, nil
+}
+
+/**
+ * Join path elements with the separator of the system.
+ * e.g. pathJoin("data", "2024", "sales.csv")
+ * The path functions are prefixed with "path" because "join" already joins the elements of an array, and "base",
+ * "dir" and "ext" are common names for variables.
+ * @param args :[]*Variable - The path elements.
+ * @return *Variable - The joined path.
+ * @return error - The error if one occurs.
+**/
+func PathJoin(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) == 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_AT_LEAST_ARGUMENT, "1", "pathJoin"), startLine)
+	}
+
+	if err := checkStringArgs("pathJoin", args, len(args), len(args), startLine); err != nil {
+		return NullVariable(), err
+	}
+
+	elements := make([]string, len(args))
+	for i, arg := range args {
+		elements[i] = arg.Value.(string)
+	}
+
+	variable := CreateVariable(filepath.Join(elements...))
+	return &variable, nil
+}
+
+/**
+ * Apply a function to a path.
+ * @param name : string - The name of the Kode function.
+ * @param args :[]*Variable - The path.
+ * @param function : func(string) string - The function to apply.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func applyPathFunction(name string, args []*Variable, startLine int, function func(string) string) (*Variable, *ErrorStack) {
+	if err := checkStringArgs(name, args, 1, 1, startLine); err != nil {
+		return NullVariable(), err
+	}
+
+	variable := CreateVariable(function(args[0].Value.(string)))
+	return &variable, nil
+}
+
+/**
+ * Get the last element of a path (e.g. "sales.csv").
+ * @param args :[]*Variable - The path.
+ * @return *Variable - The last element.
+ * @return error - The error if one occurs.
+**/
+func PathBase(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return applyPathFunction("pathBase", args, startLine, filepath.Base)
+}
+
+/**
+ * Get a path without its last element (e.g. "data/2024").
+ * @param args :[]*Variable - The path.
+ * @return *Variable - The path of the parent directory.
+ * @return error - The error if one occurs.
+**/
+func PathDir(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return applyPathFunction("pathDir", args, startLine, filepath.Dir)
+}
+
+/**
+ * Get the extension of a path (e.g. ".csv").
+ * @param args :[]*Variable - The path.
+ * @return *Variable - The extension, or an empty string if the path has none.
+ * @return error - The error if one occurs.
+**/
+func PathExt(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return applyPathFunction("pathExt", args, startLine, filepath.Ext)
+}
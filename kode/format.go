@@ -0,0 +1,213 @@
+package kode
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * Format a variable to be displayed (e.g. by "print" and "toString").
+ * Top-level strings are displayed as is, while strings nested inside arrays and objects are quoted.
+ * @param variable : Variable - The variable to format.
+ * @return string - The formatted variable.
+ */
+func FormatVariable(variable Variable) string {
+	return formatValue(variable, false, false, map[interface{}]bool{})
+}
+
+/**
+ * Format a variable as Kode source code. For data values (null, bool, int, float, string and arrays of them),
+ * the result evaluates back to an equal value.
+ * @param variable : Variable - The variable to format.
+ * @return string - The representation of the variable.
+ */
+func ReprVariable(variable Variable) string {
+	return formatValue(variable, true, true, map[interface{}]bool{})
+}
+
+/**
+ * Format a value recursively.
+ * @param variable : Variable - The variable to format.
+ * @param repr : bool - True to produce a representation that parses back to the value.
+ * @param quoted : bool - True to quote strings.
+ * @param visited : map[interface{}]bool - The arrays and objects being formatted, used to stop on cycles.
+ * @return string - The formatted value.
+ */
+func formatValue(variable Variable, repr bool, quoted bool, visited map[interface{}]bool) string {
+	switch value := variable.Value.(type) {
+	case nil:
+		return "null"
+	case string:
+		if quoted {
+			return QuoteString(value)
+		}
+		return value
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		if repr {
+			return FormatFloatLiteral(value)
+		}
+		return fmt.Sprintf("%g", value)
+	case bool:
+		return strconv.FormatBool(value)
+	case *Array:
+		if visited[value] {
+			return "[...]"
+		}
+		visited[value] = true
+		defer delete(visited, value)
+
+		elements := make([]string, len(value.Elements))
+		for i, element := range value.Elements {
+			elements[i] = formatValue(element, repr, true, visited)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case *regexp.Regexp:
+		return "regex(" + QuoteString(value.String()) + ")"
+	case *CSVReader:
+		return "csv(" + value.Source + ")"
+	case time.Time:
+		formatted, _ := formatTimeLayout(value, "iso")
+		if repr {
+			return "datetime(" + QuoteString(formatted) + ")"
+		}
+		return formatted
+	case Function:
+		if IsInstance(value) {
+			return formatInstance(value, repr, visited)
+		}
+		return FunctionSignature(value)
+	default:
+		return "unknown"
+	}
+}
+
+/**
+ * Format the fields of an object. Methods and system variables are not displayed.
+ * @param instance : Function - The object to format.
+ * @return string - The formatted object, e.g. Point{x: 1, y: 2}.
+ */
+func formatInstance(instance Function, repr bool, visited map[interface{}]bool) string {
+	key := variableMapKey(instance.Variables)
+	if visited[key] {
+		return instance.Name + "{...}"
+	}
+	visited[key] = true
+	defer delete(visited, key)
+
+	names := ObjectFields(instance)
+	fields := make([]string, len(names))
+	for i, name := range names {
+		fields[i] = name + ": " + formatValue(*instance.Variables[name], repr, true, visited)
+	}
+	return instance.Name + "{" + strings.Join(fields, ", ") + "}"
+}
+
+/**
+ * Get the names of the fields of an object, in the order of Function.Fields and otherwise sorted to get a stable
+ * output. Methods and system variables are excluded.
+ * @param instance : Function - The object.
+ * @return []string - The names of the fields.
+ */
+func ObjectFields(instance Function) []string {
+	names := []string{}
+	for name, field := range instance.Variables {
+		if field == nil || instance.isSystemVariable(name) || (*field).Type == "func" && !IsInstance((*field).Value.(Function)) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if instance.Fields == nil {
+		return names
+	}
+
+	// The ordered fields first, then the ones added afterwards
+	listed := map[string]bool{}
+	for _, name := range names {
+		listed[name] = false
+	}
+	ordered := []string{}
+	for _, name := range instance.Fields {
+		if done, exists := listed[name]; exists && !done {
+			ordered = append(ordered, name)
+			listed[name] = true
+		}
+	}
+	for _, name := range names {
+		if !listed[name] {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered
+}
+
+/**
+ * Get the signature of a function, e.g. func add(int a, int b) int.
+ * @param function : Function - The function.
+ * @return string - The signature of the function.
+ */
+func FunctionSignature(function Function) string {
+	arguments := make([]string, len(function.Arguments))
+	for i, argument := range function.Arguments {
+		arguments[i] = (*argument.Variable).Type + " " + argument.Name
+		if (*argument.Variable).Constant {
+			arguments[i] = "readonly " + arguments[i]
+		}
+	}
+
+	signature := "func " + function.Name + "(" + strings.Join(arguments, ", ") + ")"
+	if function.Return != "null" && function.Return != "" {
+		signature += " " + function.Return
+	}
+	return signature
+}
+
+/**
+ * Check if a function value is an object (i.e. the scope of a function returned with "self").
+ * @param function : Function - The function value.
+ * @return bool - True if the function is an object.
+ */
+func IsInstance(function Function) bool {
+	return function.Parent != nil && sameVariableMap(function.Parent.Variables, function.Variables)
+}
+
+/**
+ * Quote a string so it can be parsed back by Kode.
+ * @param str : string - The string to quote.
+ * @return string - The quoted string.
+ */
+func QuoteString(str string) string {
+	str = strings.ReplaceAll(str, "\"", "\\\"")
+	str = strings.ReplaceAll(str, "\n", "\\n")
+	return "\"" + str + "\""
+}
+
+/**
+ * Format a float so it is parsed back as a float (e.g. 1.0 instead of 1). Infinities and NaN are written with the
+ * INF and NAN constants.
+ * @param f : float64 - The float to format.
+ * @return string - The formatted float.
+ */
+func FormatFloatLiteral(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "INF"
+	case math.IsInf(f, -1):
+		return "-INF"
+	case math.IsNaN(f):
+		return "NAN"
+	}
+
+	str := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(str, ".") {
+		str += ".0"
+	}
+	return str
+}
@@ -0,0 +1,111 @@
+package kode
+
+import "strings"
+
+/**
+ * Format Kode code: blocks are indented, spaces outside of strings and comments are collapsed, trailing spaces and
+ * repeated blank lines are removed.
+ * @param code : string - The code to format.
+ * @param indent : string - The indentation of one block level (e.g. 4 spaces).
+ * @return string - The formatted code, ending with a single line break.
+ */
+func FormatCode(code string, indent string) string {
+	lines := LineParse(strings.ReplaceAll(code, "\r\n", "\n"))
+	aliases := codeKeywords(code, "")
+	formatted := []string{}
+	level := 0
+	blank := false
+
+	for i, line := range lines {
+
+		// Keep the shebang line as is
+		if i == 0 && strings.HasPrefix(line, "#!") {
+			formatted = append(formatted, strings.TrimRight(line, " \t\r"))
+			continue
+		}
+
+		line = collapseSpaces(strings.TrimSpace(line))
+
+		// Keep at most one blank line in a row, and none at the start
+		if line == "" {
+			blank = len(formatted) > 0
+			continue
+		}
+		if blank {
+			formatted = append(formatted, "")
+			blank = false
+		}
+
+		lineLevel := level
+		tokens := LineTokens(translateKeywords(line, aliases))
+		if len(tokens) > 0 {
+			switch tokens[0] {
+			case "if", "for", "func", "try":
+				level++
+			case "else", "catch":
+				lineLevel--
+			case "end":
+				level--
+				lineLevel--
+			}
+		}
+		if lineLevel < 0 {
+			lineLevel = 0
+		}
+		if level < 0 {
+			level = 0
+		}
+
+		formatted = append(formatted, strings.Repeat(indent, lineLevel)+line)
+	}
+
+	if len(formatted) == 0 {
+		return ""
+	}
+	return strings.Join(formatted, "\n") + "\n"
+}
+
+/**
+ * Replace the runs of spaces and tabs of a line by a single space, except inside strings and comments.
+ * @param line : string - The line of code without leading and trailing spaces.
+ * @return string - The line with collapsed spaces.
+ */
+func collapseSpaces(line string) string {
+	builder := strings.Builder{}
+	isInString := false
+	previousSpace := false
+
+	for i := 0; i < len(line); i++ {
+		char := line[i]
+
+		if isInString {
+			builder.WriteByte(char)
+			if char == '\\' && i+1 < len(line) {
+				i++
+				builder.WriteByte(line[i])
+			} else if char == '"' {
+				isInString = false
+			}
+			continue
+		}
+
+		switch char {
+		case '#':
+			// Keep comments as is
+			builder.WriteString(line[i:])
+			return builder.String()
+		case ' ', '\t':
+			if !previousSpace {
+				builder.WriteByte(' ')
+			}
+			previousSpace = true
+			continue
+		case '"':
+			isInString = true
+		}
+		builder.WriteByte(char)
+		previousSpace = false
+	}
+
+	return builder.String()
+}
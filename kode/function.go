@@ -30,15 +30,25 @@ type Argument struct {
 // ! Parent : The reference to the parent function.
 // -------------------------
 // ! Name : The name of the function.
+// -------------------------
+// ! Index : The line of the program where the scope is declared (e.g. the "func" or "if" statement). The code
+// starts on the next line. The main scope is declared on line 0.
+// -------------------------
+// ! Interpreter : The interpreter running the function.
+// -------------------------
+// ! Fields : The order of the fields of an object built from ordered data (e.g. the columns of a CSV record). The
+// fields are sorted by name if it is nil.
 // ? TODO (Eduard): Precompile the functions instead of parsing them every time.
 type Function struct {
-	Arguments []Argument
-	Variables map[string](*Variable)
-	Return    string
-	Code      string
-	Parent    *Function
-	Name      string
-	Index     int
+	Arguments   []Argument
+	Variables   map[string](*Variable)
+	Return      string
+	Code        string
+	Parent      *Function
+	Name        string
+	Index       int
+	Interpreter *Interpreter
+	Fields      []string
 }
 
 /**
@@ -64,8 +74,9 @@ func CreateFunction(name string, index int, argumentsTemplate []Argument, variab
 	for _, arg := range argumentsTemplate {
 
 		vars[arg.Name] = &Variable{
-			Value: GetDefaultValue((*arg.Variable).Type),
-			Type:  (*arg.Variable).Type,
+			Value:    GetDefaultValue((*arg.Variable).Type),
+			Type:     (*arg.Variable).Type,
+			Constant: (*arg.Variable).Constant,
 		}
 	}
 
@@ -81,11 +92,38 @@ func CreateFunction(name string, index int, argumentsTemplate []Argument, variab
 
 	if parent == nil {
 		function.Parent = &function
+	} else {
+		function.Interpreter = (*parent).Interpreter
 	}
 
 	return function
 }
 
+/**
+ * Get the line of the program of a line of the code of the scope.
+ * @param currentLine : int - The index of the line in the code of the scope.
+ * @return int - The line in the program, starting at 1.
+ */
+func (scope *Function) LineAt(currentLine int) int {
+	return (*scope).Index + currentLine + 1
+}
+
+/**
+ * Create the error giving the function in which an error occurred, on the line where the function is declared.
+ * The main scope and the blocks (e.g. "if" or "for") give no context: the location of the error is enough.
+ * @return *ErrorStack - The error, e.g. In function "add" on line 3, or nil for the main scope and the blocks.
+ */
+func (scope *Function) ContextError() *ErrorStack {
+	if (*scope).Index == 0 {
+		return nil // The main scope is declared on line 0
+	}
+	switch (*scope).Name {
+	case "if", "for", "try", "catch":
+		return nil
+	}
+	return CreateError(NewMessage(MSG_IN_FUNCTION, (*scope).Name), (*scope).Index)
+}
+
 /**
  * Add argument variables to the current scope.
  * @param args : []*Variable - The arguments to add.
@@ -100,18 +138,27 @@ func (scope *Function) ArgumentsToVariables(args []*Variable, startLine int) *Er
 
 		// Check if there are more arguments than variables for the function
 		if i >= len((*scope).Arguments) {
-			return CreateError("Error: Argument count (expected at most "+strconv.FormatInt(int64(len((*scope).Arguments)), 10)+") mismatch for \""+(*scope).Name+"\"", startLine)
+			return CreateError(ErrorMessage(MSG_TOO_MANY_ARGS, strconv.FormatInt(int64(len((*scope).Arguments)), 10), (*scope).Name), startLine)
 		}
 
 		// Determine if the type of the variable is compatible with the function argument
 		// ! Exception: If the function argument is "val" then it is compatible with any type.
 		if (*scope).Arguments[i].Variable.Type == (*arg).Type || (*scope).Arguments[i].Variable.Type == "val" {
 
-			if (*arg).Type == "string" || (*arg).Type == "int" || (*arg).Type == "float" || (*arg).Type == "bool" {
+			if (*scope).Arguments[i].Variable.Constant {
+
+				// Read-only parameters receive a frozen copy so the caller's data cannot be modified
+				varCopy := FreezeVariable(*arg)
+				varCopy.Constant = true
+				(*scope).Variables[(*scope).Arguments[i].Name] = &varCopy
+
+			} else if (*arg).Type == "string" || (*arg).Type == "int" || (*arg).Type == "float" || (*arg).Type == "bool" {
 
 				// Create a copy of the variable
 				// And set the variable value inside the function scope
 				varCopy := *arg
+				varCopy.Constant = false
+				varCopy.Frozen = false
 				(*scope).Variables[(*scope).Arguments[i].Name] = &varCopy
 
 			} else {
@@ -121,33 +168,32 @@ func (scope *Function) ArgumentsToVariables(args []*Variable, startLine int) *Er
 			}
 
 		} else {
-			return CreateError("Error: Argument type mismatch for the argument \""+(*scope).Arguments[i].Name+"\"", startLine)
+			return CreateError(ErrorMessage(MSG_ARGUMENT_TYPE, (*scope).Arguments[i].Name), startLine)
 		}
 	}
 
 	return nil // No error
 }
 
-func (scope *Function) ExtractFunctionArgs(queue *Queue, depth int64, startLine int) ([]*Variable, *ErrorStack) {
+func (scope *Function) ExtractFunctionArgs(tokens *Tokens, depth int64, startLine int) ([]*Variable, *ErrorStack) {
 
 	// Evaluate the function
-	parentheses, _ := (*queue).Pop()
+	parentheses, _ := tokens.Pop()
 	if parentheses == nil || parentheses.(string) != "(" {
-		return nil, CreateError("Error: Missing parentheses for the function \""+(*scope).Name+"\"", startLine)
+		return nil, CreateError(ErrorMessage(MSG_MISSING_PARENTHESES, (*scope).Name), startLine).At(tokens.Last())
 	}
+	opening := tokens.Last()
 
 	parameters := []*Variable{}
 	closedFunction := false
-	tempVariable := ""
+	first := tokens.Index() // The index of the first token of the parameter
 	isInString := false
 	nestedParenthesis := 0
 	// Get the function parameters
-	for !queue.IsEmpty() {
+	for !tokens.IsEmpty() {
 
 		// Get the next token
-		nextToken, _ := queue.Pop()
-
-		//println(nextToken.(string))
+		nextToken, _ := tokens.Pop()
 
 		// Check if the token is the end of the function parameters
 		if (nextToken.(string) == "(" || nextToken.(string) == "[") && !isInString {
@@ -160,17 +206,16 @@ func (scope *Function) ExtractFunctionArgs(queue *Queue, depth int64, startLine
 			nestedParenthesis -= 1
 		} else if nextToken.(string) == "," && nestedParenthesis == 0 && !isInString {
 
-			//println(tempVariable)
-
 			// Evaluate the parameter
-			parameter, err := EvaluateExpression(scope, tempVariable, depth, startLine)
+			span := tokens.Between(first, tokens.Index()-1)
+			parameter, err := EvaluateExpression(scope, tokens.Text(span), depth, startLine, span.Start)
 			if err != nil {
 				return nil, err
 			}
 
 			parameters = append(parameters, &parameter)
 
-			tempVariable = ""
+			first = tokens.Index()
 			continue
 		}
 
@@ -178,23 +223,15 @@ func (scope *Function) ExtractFunctionArgs(queue *Queue, depth int64, startLine
 		if nextToken.(string) == "\"" {
 			isInString = !isInString
 		}
-
-		// If the token is not a string, and the string is not closed, add the token to the temp variable
-		if isInString {
-			tempVariable += nextToken.(string)
-		} else {
-			tempVariable += nextToken.(string) + " "
-		}
 	}
 
 	if !closedFunction {
-		return nil, CreateError("Error: Missing closing parentheses for the function call \""+(*scope).Name+"\"", startLine)
+		return nil, CreateError(ErrorMessage(MSG_MISSING_CALL_CLOSING, (*scope).Name), startLine).At(opening)
 	}
 
 	// Evaluate the last parameter
-	if tempVariable != "" {
-		//println(tempVariable)
-		parameter, err := EvaluateExpression(scope, tempVariable, depth, startLine)
+	if span := tokens.Between(first, tokens.Index()-1); !span.IsEmpty() {
+		parameter, err := EvaluateExpression(scope, tokens.Text(span), depth, startLine, span.Start)
 		if err != nil {
 			return nil, err
 		}
@@ -210,6 +247,7 @@ func (scope *Function) ExtractFunctionArgs(queue *Queue, depth int64, startLine
  * @param args : interface{} - The arguments of the function.
  * @param vars : map[string](*Variable) - The variables of the function.
  * @param depth : int - The current depth of the function.
+ * @param startLine : int - The line of the program calling the function, for the errors of the call.
  * @return *Variable - Return of the function.
  * @return int - The return type of the function.
 		** 0: Immediate return
@@ -222,12 +260,12 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 	// Limit the depth of the function recursion
 	if (*scope).VariableExists("_MAX_RECURSION") && EvaluateType((*(*scope).GetVariable("_MAX_RECURSION")).Value) == "int" {
 		if depth > (*(*scope).GetVariable("_MAX_RECURSION")).Value.(int64) {
-			return nil, 0, CreateError("Error: Recursion limit reached (_MAX_RECURSION)", startLine)
+			return nil, 0, CreateError(ErrorMessage(MSG_RECURSION_LIMIT, "_MAX_RECURSION"), startLine).At((*scope).GetInterpreter().callSpan())
 		}
 	} else {
 		// Could not find the variable _MAX_RECURSION, default max depth to 5000
 		if depth > 5000 {
-			return nil, 0, CreateError("Error: Recursion limit reached (5000)", startLine+(*scope).Index)
+			return nil, 0, CreateError(ErrorMessage(MSG_RECURSION_LIMIT, "5000"), startLine).At((*scope).GetInterpreter().callSpan())
 		}
 	}
 
@@ -257,17 +295,14 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 
 		// Get the command tokens of the line.
 		// Add the tokens to the queue.
-		tokens := Queue{}
-		tokensArr := InlineParse(line, []string{" ", "\t", "\r", "[", "]", "!=", "==", ">=", "<=", "=", ":=", "#", "\"", "\\\"", ",", ".", "(", ")", "\""}, true)
-		for _, token := range tokensArr {
-			tokens.Push(token)
-		}
+		tokens := NewLineTokens(line)
 
 		// Loop through the tokens.
 		for !tokens.IsEmpty() {
 			// First token is the command.
 			// The command determines the action.
 			command, _ := tokens.Pop()
+			commandSpan := tokens.Last()
 
 			switch command {
 
@@ -283,13 +318,24 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 			// ? Variable creation
 			// The variable is created in the current scope of the function.
 			// "val" <name> = <value> where the type is inferred from the value.
-			case "val", "int", "float", "string", "bool":
+			// "const" [type] <name> = <value> creates a variable that cannot be changed.
+			case "val", "int", "float", "string", "bool", "const":
+
+				// Constants infer their type unless one is provided
+				constant := command.(string) == "const"
+				if constant {
+					command = "val"
+					if typeName, ok := tokens.Peek(); ok && IsTypeName(typeName.(string)) {
+						command = typeName
+						tokens.Pop()
+					}
+				}
 
 				// Get the dimensions of the variable.
 				// The dimensions are optional.
-				dimension, err := ExtractArrayDimensionFromDeclaration(&tokens, currentLine+1)
+				dimension, err := ExtractArrayDimensionFromDeclaration(&tokens.Queue, (*scope).LineAt(currentLine))
 				if err != nil {
-					return nil, 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+					return nil, 0, err.AddError((*scope).ContextError())
 				}
 
 				command = command.(string) + strings.Repeat("[]", dimension)
@@ -299,17 +345,18 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 
 				// Check if the name for the variable was provided
 				if !nameProvided {
-					return NullVariable(), 0, CreateError("Error: Missing variable name", startLine+currentLine+(*scope).Index)
+					return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_MISSING_NAME), (*scope).LineAt(currentLine)).At(commandSpan)
 				}
+				nameSpan := tokens.Last()
 
 				// Check if the variable name is valid
 				if !HasValidVariableName(name.(string)) {
-					return nil, 0, CreateError("Error: Variable names must be alphanumeric and start with a letter. Invalid variable name \""+name.(string)+"\"", startLine+currentLine+(*scope).Index)
+					return nil, 0, CreateSyntaxError(ErrorMessage(MSG_VARIABLE_NAME, name.(string)), (*scope).LineAt(currentLine)).At(nameSpan)
 				}
 
 				// Check if the variable name is already in use in the current scope
 				if (*scope).VariableExists(name.(string)) {
-					return NullVariable(), 0, CreateError("Error: Variable \""+name.(string)+"\" already exists in the current scope", startLine+currentLine+(*scope).Index)
+					return NullVariable(), 0, CreateError(ErrorMessage(MSG_VARIABLE_EXISTS, name.(string)), (*scope).LineAt(currentLine)).At(nameSpan)
 				}
 
 				// Get the expected variable declaration format
@@ -318,31 +365,29 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 
 				// Check if the variable has an assignment
 				if !assign || equal.(string) != "=" {
-					return NullVariable(), 0, CreateError("Error: Missing assignment for variable \""+name.(string)+"\"", startLine+currentLine+(*scope).Index)
+					return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_MISSING_ASSIGN, name.(string)), (*scope).LineAt(currentLine)).At(nameSpan)
 				}
 
-				// Get the rest of the line tokens and join them to feed the variable value
-				// Convert queue tokens back into a string
-				// Also, account that quotes define a string
-				value := InlineQueueToString(&tokens)
+				// Get the rest of the line to feed the variable value
+				value := tokens.Rest()
 
 				// Make sure the variable value is not empty
-				if value == "" {
-					return NullVariable(), 0, CreateError("Error: Missing value for variable \""+name.(string)+"\"", startLine+currentLine+(*scope).Index)
+				if value.IsEmpty() {
+					return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_MISSING_VALUE, name.(string)), (*scope).LineAt(currentLine)).At(nameSpan)
 				}
 
 				// Create the variable and evaluate the value
-				evaluatedValue, err := EvaluateExpression(scope, value, depth, startLine+currentLine+(*scope).Index)
+				evaluatedValue, err := EvaluateExpression(scope, tokens.Text(value), depth, (*scope).LineAt(currentLine), value.Start)
 				if err != nil {
-					return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+					return NullVariable(), 0, err.AddError((*scope).ContextError())
 				}
 
 				if command.(string) != "val" && evaluatedValue.Type != command.(string) {
 
 					// If the evaluated value is an empty array (e.g. []), then its evaluated type would be "val[]" and its length would be 0.
 					// Empty arrays are allowed to be assigned to any array type.
-					if !isArrayType(command.(string)) || evaluatedValue.Type != "val[]" || len(evaluatedValue.Value.([]Variable)) != 0 {
-						return NullVariable(), 0, CreateError("Error: Variable \""+name.(string)+"\" cannot be assigned to type \""+command.(string)+"\"", startLine+currentLine+(*scope).Index)
+					if !isArrayType(command.(string)) || evaluatedValue.Type != "val[]" || len(evaluatedValue.Elements()) != 0 {
+						return NullVariable(), 0, CreateError(ErrorMessage(MSG_TYPE_MISMATCH, name.(string), command.(string)), (*scope).LineAt(currentLine)).At(value)
 					} else {
 						// Properly assign the variable type for the empty array
 						evaluatedValue.Type = command.(string)
@@ -350,6 +395,16 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 
 				}
 
+				// Constants are deep-frozen so neither the variable nor its elements and fields can change
+				// Other variables declared from the arrays of a constant get a modifiable copy (e.g. int[] row = matrix[0])
+				if constant {
+					evaluatedValue = FreezeVariable(evaluatedValue)
+				} else if array, ok := evaluatedValue.Value.(*Array); ok && array.Constant {
+					evaluatedValue = CopyValue(evaluatedValue, true)
+				}
+				evaluatedValue.Constant = constant
+				evaluatedValue.Frozen = false
+
 				// Create the variable in the current scope.
 				(*scope).Variables[name.(string)] = &evaluatedValue
 				if (*scope).GetVariable("_DEBUG").Type == "bool" && (*scope).GetVariable("_DEBUG").Value.(bool) {
@@ -362,9 +417,9 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 			case "if":
 
 				// Parse the condition blocks
-				conditionBlocks, nextLine, err := ParseConditionBlocks(&tokens, currentLine, lines, startLine+(*scope).Index)
+				conditionBlocks, nextLine, err := ParseConditionBlocks(tokens, currentLine, lines, (*scope).LineAt(0))
 				if err != nil {
-					return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+					return NullVariable(), 0, err.AddError((*scope).ContextError())
 				}
 
 				currentLine = nextLine // Update the current line to skip the code block
@@ -380,12 +435,12 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 					if conditionBlock.Condition == "else" {
 
 						// Directly execute the code
-						ifCondition := CreateFunction("if", startLine+currentLine, []Argument{}, (*scope).Variables, "val", scope, conditionBlock.Code)
-						returnValue, toReturn, err := ifCondition.Run([]*Variable{}, map[string]*Variable{}, depth, startLine+currentLine+(*scope).Index)
+						ifCondition := CreateFunction("if", (*scope).LineAt(conditionBlock.ConditionIndex), []Argument{}, (*scope).Variables, "val", scope, conditionBlock.Code)
+						returnValue, toReturn, err := ifCondition.Run([]*Variable{}, map[string]*Variable{}, depth, (*scope).LineAt(conditionBlock.ConditionIndex))
 
 						// If the code returns a value, return it
 						if err != nil {
-							return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+							return NullVariable(), 0, err.AddError((*scope).ContextError())
 						}
 
 						// Continue returning the value
@@ -400,15 +455,15 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 
 						// Is an if statement or if else statement
 						// Evaluate the condition
-						evaluatedCondition, err := EvaluateExpression(scope, conditionBlock.Condition, depth, startLine+currentLine+(*scope).Index)
+						evaluatedCondition, err := EvaluateExpression(scope, conditionBlock.Condition, depth, (*scope).LineAt(conditionBlock.ConditionIndex), conditionBlock.ConditionOffset)
 						if err != nil {
-							return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+							return NullVariable(), 0, err.AddError((*scope).ContextError())
 						}
 
 						// Check the type of the evaluated condition
 						// If it is not a boolean, return an error
 						if evaluatedCondition.Type != "bool" {
-							return NullVariable(), 0, CreateError("Error: Condition must be a boolean", startLine+currentLine+(*scope).Index)
+							return NullVariable(), 0, CreateError(ErrorMessage(MSG_CONDITION_BOOL), (*scope).LineAt(conditionBlock.ConditionIndex)).At(Span{conditionBlock.ConditionOffset, conditionBlock.ConditionOffset + len(conditionBlock.Condition)})
 						}
 
 						// If the condition is true, execute the block of code
@@ -416,11 +471,11 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 
 							// Create a new scope for the block
 
-							ifCondition := CreateFunction("if", startLine+currentLine, []Argument{}, (*scope).Variables, "val", scope, conditionBlock.Code)
-							returnValue, toReturn, err := ifCondition.Run([]*Variable{}, map[string]*Variable{}, depth, startLine+currentLine+(*scope).Index)
+							ifCondition := CreateFunction("if", (*scope).LineAt(conditionBlock.ConditionIndex), []Argument{}, (*scope).Variables, "val", scope, conditionBlock.Code)
+							returnValue, toReturn, err := ifCondition.Run([]*Variable{}, map[string]*Variable{}, depth, (*scope).LineAt(conditionBlock.ConditionIndex))
 
 							if err != nil {
-								return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+								return NullVariable(), 0, err.AddError((*scope).ContextError())
 							}
 
 							if toReturn > 0 {
@@ -451,26 +506,26 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 
 				// Check if the name for the function was provided
 				if !nameProvided {
-					return NullVariable(), 0, CreateError("Error: Function name not provided", startLine+currentLine+(*scope).Index)
+					return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_MISSING_FUNCTION), (*scope).LineAt(currentLine))
 				}
 
 				// Check if the function name is valid
 				// Again, they act like variables
 				if !HasValidVariableName(name.(string)) {
-					return NullVariable(), 0, CreateError("Error: The function name must be alphanumeric. Invalid function name \""+name.(string)+"\"", startLine+currentLine+(*scope).Index)
+					return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_FUNCTION_NAME, name.(string)), (*scope).LineAt(currentLine))
 				}
 
 				// Check if the function name is already in use in the current scope
 				// A function and primitive variable cannot have the same name
 				if (*scope).VariableExists(name.(string)) {
-					return NullVariable(), 0, CreateError("Error: The function/variable name \""+name.(string)+"\" is already in use", startLine+currentLine+(*scope).Index)
+					return NullVariable(), 0, CreateError(ErrorMessage(MSG_NAME_IN_USE, name.(string)), (*scope).LineAt(currentLine))
 				}
 
 				// Get the parameters for the function
 				// Check if the function parameters start with a parentheses
 				char, charProvided := tokens.Pop()
 				if !charProvided || char.(string) != "(" {
-					return NullVariable(), 0, CreateError("Error: Function parameters must start with a parentheses", startLine+currentLine+(*scope).Index)
+					return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_PARAMETERS_START), (*scope).LineAt(currentLine))
 				}
 
 				// Parameters list
@@ -483,23 +538,33 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 					token, tokenProvided := tokens.Pop()
 
 					if !tokenProvided {
-						return NullVariable(), 0, CreateError("Error: Expected a closing parenthesis", startLine+currentLine+(*scope).Index)
+						return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_EXPECTED_CLOSING), (*scope).LineAt(currentLine))
 					}
 
 					if token.(string) == ")" {
 						break
 					} else {
+						// Check for the read-only modifier
+						// e.g. "readonly int[] values" or "let int[] values"
+						readOnly := token.(string) == "readonly" || token.(string) == "let"
+						if readOnly {
+							token, tokenProvided = tokens.Pop()
+							if !tokenProvided {
+								return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_EXPECTED_TYPE), (*scope).LineAt(currentLine))
+							}
+						}
+
 						// Check if the parameter type is valid
 						// If it is not, return an error
 						if token.(string) != "val" && token.(string) != "int" && token.(string) != "float" && token.(string) != "bool" && token.(string) != "string" {
-							return NullVariable(), 0, CreateError("Error: Invalid parameter type \""+token.(string)+"\"", startLine+currentLine+(*scope).Index)
+							return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_PARAMETER_TYPE, token.(string)), (*scope).LineAt(currentLine))
 						}
 
 						// Get the dimensions of the variable.
 						// The dimensions are optional.
-						dimension, err := ExtractArrayDimensionFromDeclaration(&tokens, startLine)
+						dimension, err := ExtractArrayDimensionFromDeclaration(&tokens.Queue, (*scope).LineAt(currentLine))
 						if err != nil {
-							return nil, 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+							return nil, 0, err.AddError((*scope).ContextError())
 						}
 
 						token = token.(string) + strings.Repeat("[]", dimension)
@@ -507,21 +572,22 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 						// Get the parameter name
 						parameterName, parameterNameProvided := tokens.Pop()
 						if !parameterNameProvided {
-							return NullVariable(), 0, CreateError("Error: Expected a parameter name", startLine+currentLine+(*scope).Index)
+							return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_EXPECTED_PARAMETER), (*scope).LineAt(currentLine))
 						}
 
 						// Check if the parameter name is valid
 						// If it is not, return an error
 						if !HasValidVariableName(parameterName.(string)) {
-							return NullVariable(), 0, CreateError("Error: The parameter name must be alphanumeric. Invalid parameter name \""+parameterName.(string)+"\"", startLine+currentLine+(*scope).Index)
+							return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_PARAMETER_NAME, parameterName.(string)), (*scope).LineAt(currentLine))
 						}
 
 						// Create the parameter
 						parameters = append(parameters, Argument{
 							Name: parameterName.(string),
 							Variable: &Variable{
-								Type:  token.(string),
-								Value: nil,
+								Type:     token.(string),
+								Value:    nil,
+								Constant: readOnly,
 							},
 						})
 
@@ -529,7 +595,7 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 						// If it is, continue the loop
 						token, tokenProvided = tokens.Pop()
 						if !tokenProvided {
-							return NullVariable(), 0, CreateError("Error: Expected a comma or a closing parenthesis", startLine+currentLine+(*scope).Index)
+							return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_EXPECTED_COMMA), (*scope).LineAt(currentLine))
 						}
 
 						if token.(string) == "," {
@@ -537,7 +603,7 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 						} else if token.(string) == ")" {
 							break
 						} else {
-							return NullVariable(), 0, CreateError("Error: Expected a comma or a closing parenthesis", startLine+currentLine+(*scope).Index)
+							return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_EXPECTED_COMMA), (*scope).LineAt(currentLine))
 						}
 
 					}
@@ -550,18 +616,19 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 				if !returnTypeProvided {
 					returnType = "null"
 				} else if returnType.(string) != "val" && returnType.(string) != "int" && returnType.(string) != "float" && returnType.(string) != "bool" && returnType.(string) != "string" && returnType.(string) != "func" {
-					return NullVariable(), 0, CreateError("Error: Invalid return type \""+returnType.(string)+"\"", startLine+currentLine+(*scope).Index)
+					return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_RETURN_TYPE, returnType.(string)), (*scope).LineAt(currentLine))
 				}
 
 				// If its an array, get the dimensions
-				dimensions, err := ExtractArrayDimensionFromDeclaration(&tokens, startLine+currentLine+(*scope).Index)
+				dimensions, err := ExtractArrayDimensionFromDeclaration(&tokens.Queue, (*scope).LineAt(currentLine))
 				if err != nil {
-					return nil, 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+					return nil, 0, err.AddError((*scope).ContextError())
 				}
 				// Apply the dimensions to the return type
 				returnType = returnType.(string) + strings.Repeat("[]", dimensions)
 
 				// Get the block of code for the function
+				funcLine := currentLine
 				funcEnded := false
 				functionCode := ""
 				currentLine++
@@ -579,28 +646,25 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 				}
 
 				if !funcEnded {
-					return NullVariable(), 0, CreateError("Error: Expected \"end "+name.(string)+"\"", startLine+(*scope).Index)
+					return NullVariable(), 0, unclosedBlockError("func", name.(string), lines, funcLine, (*scope).LineAt(0))
 				}
 
 				// Create the function and add it to the scope
-				function := CreateVariable(CreateFunction(name.(string), startLine+currentLine, parameters, make(map[string]*Variable), returnType.(string), scope, functionCode))
+				function := CreateVariable(CreateFunction(name.(string), (*scope).LineAt(funcLine), parameters, make(map[string]*Variable), returnType.(string), scope, functionCode))
 				(*scope).Variables[name.(string)] = &function
 
 			case "return":
 				// Retrive the return value
 				// Get the return value expression
-				expression := InlineQueueToString(&tokens)
-
-				// For loop inside expression array
-				// println(expression)
+				expression := tokens.Rest()
 
 				returnValue := Variable{}
 				// Evaluate the expression
-				if expression != "" {
+				if !expression.IsEmpty() {
 					// Evaluate the expression
-					value, err := EvaluateExpression(scope, expression, depth, startLine+currentLine+(*scope).Index)
+					value, err := EvaluateExpression(scope, tokens.Text(expression), depth, (*scope).LineAt(currentLine), expression.Start)
 					if err != nil {
-						return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+						return NullVariable(), 0, err.AddError((*scope).ContextError())
 					}
 					// Set the return value
 					returnValue = value
@@ -613,7 +677,7 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 				} else if returnValue.Type == (*scope).Return || (*scope).Return == "val" {
 					return &returnValue, 1, nil
 				} else {
-					return NullVariable(), 1, CreateError("Error: Invalid return type \""+returnValue.Type+"\"", startLine+currentLine+(*scope).Index)
+					return NullVariable(), 1, CreateError(ErrorMessage(MSG_RETURN_TYPE, returnValue.Type), (*scope).LineAt(currentLine)).At(expression)
 				}
 
 			case "break":
@@ -623,29 +687,42 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 
 			case "for":
 
-				loopBlock, nextLine, err := ParseLoopBlock(&tokens, currentLine, lines, startLine)
+				loopBlock, nextLine, err := ParseLoopBlock(tokens, currentLine, lines, (*scope).LineAt(0))
 
 				if err != nil {
-					return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+					return NullVariable(), 0, err.AddError((*scope).ContextError())
 				}
 
-				evaluatedCondition, err := EvaluateExpression(scope, loopBlock.Condition, depth, startLine+currentLine+(*scope).Index)
+				// For-each loop (e.g. "for item in items")
+				if name, iterable, isForEach := ParseForEach(loopBlock.Condition); isForEach {
+					returnValue, toReturn, err := (*scope).RunForEach(name, iterable, loopBlock, depth, (*scope).LineAt(currentLine))
+					if err != nil {
+						return NullVariable(), 0, err.AddError((*scope).ContextError())
+					}
+					if toReturn == 1 {
+						return returnValue, toReturn, nil
+					}
+					currentLine = nextLine
+					break
+				}
+
+				evaluatedCondition, err := EvaluateExpression(scope, loopBlock.Condition, depth, (*scope).LineAt(currentLine), loopBlock.ConditionOffset)
 				if err != nil {
-					return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+					return NullVariable(), 0, err.AddError((*scope).ContextError())
 				}
 
 				if evaluatedCondition.Type != "bool" {
-					return NullVariable(), 0, CreateError("Error: Invalid condition type \""+evaluatedCondition.Type+"\"", startLine+currentLine+(*scope).Index)
+					return NullVariable(), 0, CreateError(ErrorMessage(MSG_CONDITION_TYPE, evaluatedCondition.Type), (*scope).LineAt(currentLine)).At(Span{loopBlock.ConditionOffset, loopBlock.ConditionOffset + len(loopBlock.Condition)})
 				}
 
 				for evaluatedCondition.Value.(bool) {
 
-					forLoop := CreateFunction("for", startLine+currentLine, []Argument{}, (*scope).Variables, "val", scope, loopBlock.Code)
-					returnValue, toReturn, err := forLoop.Run([]*Variable{}, map[string]*Variable{}, depth, startLine+currentLine+(*scope).Index)
+					forLoop := CreateFunction("for", (*scope).LineAt(currentLine), []Argument{}, (*scope).Variables, "val", scope, loopBlock.Code)
+					returnValue, toReturn, err := forLoop.Run([]*Variable{}, map[string]*Variable{}, depth, (*scope).LineAt(currentLine))
 
 					// If the code returns a value, return it
 					if err != nil {
-						return NullVariable(), 0, err
+						return NullVariable(), 0, err.AddError((*scope).ContextError())
 					}
 
 					// If the code returns a value, return it to the caller
@@ -659,14 +736,14 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 					}
 
 					// Evaluate the condition
-					evaluatedCondition, err = EvaluateExpression(scope, loopBlock.Condition, depth, startLine+currentLine+(*scope).Index)
+					evaluatedCondition, err = EvaluateExpression(scope, loopBlock.Condition, depth, (*scope).LineAt(currentLine), loopBlock.ConditionOffset)
 					if err != nil {
 
-						return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+						return NullVariable(), 0, err.AddError((*scope).ContextError())
 					}
 
 					if evaluatedCondition.Type != "bool" {
-						return NullVariable(), 0, CreateError("Error: Invalid condition type \""+evaluatedCondition.Type+"\"", startLine+currentLine+(*scope).Index)
+						return NullVariable(), 0, CreateError(ErrorMessage(MSG_CONDITION_TYPE, evaluatedCondition.Type), (*scope).LineAt(currentLine)).At(Span{loopBlock.ConditionOffset, loopBlock.ConditionOffset + len(loopBlock.Condition)})
 					}
 
 					// Exit the loop if the condition is false
@@ -678,6 +755,28 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 
 				currentLine = nextLine
 
+			// ? Error handling
+			// The errors of the try block are caught by the catch block.
+			case "try":
+
+				tryLine := (*scope).LineAt(currentLine)
+				tryBlock, nextLine, err := ParseTryBlock(tokens, currentLine, lines, (*scope).LineAt(0))
+				if err != nil {
+					return NullVariable(), 0, err.AddError((*scope).ContextError())
+				}
+
+				currentLine = nextLine // Update the current line to skip the code block
+
+				returnValue, toReturn, err := (*scope).RunTry(tryBlock, depth, tryLine)
+				if err != nil {
+					return NullVariable(), 0, err.AddError((*scope).ContextError())
+				}
+
+				// Continue returning the value or breaking the loop
+				if toReturn > 0 {
+					return returnValue, toReturn, nil
+				}
+
 			default:
 
 				// Is the command a variable (check inside the scope)?
@@ -685,33 +784,38 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 				if (*scope).VariableExists(command.(string)) {
 
 					variable := (*scope).GetVariable(command.(string))
+					constant := (*variable).Constant
+					frozen := false             // An indexed element belongs to a frozen array
+					field := (*variable).Frozen // The variable is a field of a frozen object
 
 					// Check for array index
 					peeked, validPeek := tokens.Peek()
 					for validPeek && peeked.(string) == "[" {
 
+						// Assigning an element does not rebind the variable itself
+						frozen = frozen || variable.IsFrozen()
+						constant = false
+						field = false
+
 						// Check if array
 						if !isArrayType((*variable).Type) {
-							return NullVariable(), 0, CreateError("Error: \""+peeked.(string)+"\" cannot access that index because it might not be an array, startLine+currentLine+(*scope).Index", startLine+currentLine+(*scope).Index)
+							return NullVariable(), 0, CreateTokenError(ErrorMessage(MSG_NOT_AN_ARRAY, command.(string)), commandSpan, (*scope).LineAt(currentLine))
 						}
 
 						tokens.Pop()
-						indexArray, err := (*scope).ExtractArrayValues(&tokens, depth, startLine+currentLine+(*scope).Index)
+						indexArray, err := (*scope).ExtractArrayValues(tokens, depth, (*scope).LineAt(currentLine))
 						if err != nil {
-							return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+							return NullVariable(), 0, err.AddError((*scope).ContextError())
 						}
 						if len(indexArray) != 1 || indexArray[0].Type != "int" {
-							return NullVariable(), 0, CreateError("Error: Invalid array index type \""+indexArray[0].Type+"\"", startLine+currentLine+(*scope).Index)
-						}
-						size := int64(len((*variable).Value.([]Variable)))
-						if size == 0 {
-							return NullVariable(), 0, CreateError("Error: Array is empty", startLine+currentLine+(*scope).Index)
+							return NullVariable(), 0, CreateError(ErrorMessage(MSG_INDEX_TYPE, indexArray[0].Type), (*scope).LineAt(currentLine)).At(commandSpan.To(tokens.Last()))
 						}
-						index := indexArray[0].Value.(int64) % size
-						if index < 0 { // Handle negative indexes
-							index += size
+						size := int64(len(variable.Elements()))
+						index, err := (*scope).ResolveIndex(indexArray[0].Value.(int64), size, (*scope).LineAt(currentLine))
+						if err != nil {
+							return NullVariable(), 0, err.At(commandSpan.To(tokens.Last())).AddError((*scope).ContextError())
 						}
-						variable = &(*variable).Value.([]Variable)[index]
+						variable = &variable.Elements()[index]
 						peeked, validPeek = tokens.Peek()
 
 					}
@@ -725,32 +829,43 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 
 						// Simply execute the command and return NO value
 
-						output := command.(string) + InlineQueueToString(&tokens)
-						_, err := EvaluateExpression(scope, output, depth, startLine+currentLine+(*scope).Index)
+						output := commandSpan.To(tokens.Rest())
+						_, err := EvaluateExpression(scope, tokens.Text(output), depth, (*scope).LineAt(currentLine), output.Start)
 
 						if err != nil {
-							return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+							return NullVariable(), 0, err.AddError((*scope).ContextError())
 						}
 
 					} else {
 
-						// Get the rest of the line tokens and join them to feed the variable value.
-
 						// Remove the equal sign
 						tokens.Pop()
 
-						value := InlineQueueToString(&tokens)
+						// Get the rest of the line to feed the variable value.
+						value := tokens.Rest()
+						statement := commandSpan.To(value)
+
+						// Constants and frozen values cannot be changed
+						if constant {
+							return NullVariable(), 0, CreateError(ErrorMessage(MSG_CONSTANT, command.(string)), (*scope).LineAt(currentLine)).At(statement)
+						}
+						if frozen {
+							return NullVariable(), 0, CreateError(ErrorMessage(MSG_FROZEN_VALUE, command.(string)), (*scope).LineAt(currentLine)).At(statement)
+						}
+						if field {
+							return NullVariable(), 0, CreateError(ErrorMessage(MSG_FROZEN_FIELD, command.(string)), (*scope).LineAt(currentLine)).At(statement)
+						}
 
 						// Make sure the variable value is valid (not empty)
-						if value == "" {
-							return NullVariable(), 0, CreateError("Error: Variable value cannot be empty", startLine+currentLine+(*scope).Index)
+						if value.IsEmpty() {
+							return NullVariable(), 0, CreateError(ErrorMessage(MSG_EMPTY_VALUE), (*scope).LineAt(currentLine)).At(statement)
 						}
 
 						// Create the new variable.
 						// Evaluate the value and update the variable.
-						evaluatedValue, err := EvaluateExpression(scope, value, depth, startLine+currentLine+(*scope).Index)
+						evaluatedValue, err := EvaluateExpression(scope, tokens.Text(value), depth, (*scope).LineAt(currentLine), value.Start)
 						if err != nil {
-							return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+							return NullVariable(), 0, err.AddError((*scope).ContextError())
 						}
 
 						// Check safe assignment
@@ -759,7 +874,7 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 							// Accept to store type[] inside val[]
 							// Although, do not change the type of the variable
 							if !isArrayType((*variable).Type) && !isArrayType(evaluatedValue.Type) && strings.ReplaceAll((*variable).Type, "[]", "") != "val" {
-								return NullVariable(), 0, CreateError("Error:"+"Expected type "+(*scope).Variables[command.(string)].Type+" but got type "+evaluatedValue.Type+"."+" Invalid assignment type \""+evaluatedValue.Type+"\"", startLine+currentLine+(*scope).Index)
+								return NullVariable(), 0, CreateError(ErrorMessage(MSG_ASSIGNMENT_TYPE, (*scope).Variables[command.(string)].Type, evaluatedValue.Type), (*scope).LineAt(currentLine)).At(value)
 							} else {
 								evaluatedValue.Type = (*variable).Type
 							}
@@ -767,6 +882,8 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 						}
 
 						// Update the variable in the current scope.
+						evaluatedValue.Constant = false
+						evaluatedValue.Frozen = false
 						*variable = evaluatedValue
 						// *((*scope).Variables[command.(string)]) = evaluatedValue
 						if (*scope).GetVariable("_DEBUG").Type == "bool" && (*scope).GetVariable("_DEBUG").Value.(bool) {
@@ -778,22 +895,29 @@ func (scope *Function) Run(args []*Variable, vars map[string](*Variable), depth
 				} else if ExistsBuiltIn(command.(string)) {
 
 					// Extract the function's arguments
-					args, err := (*scope).ExtractFunctionArgs(&tokens, depth, startLine+currentLine+(*scope).Index)
+					args, err := (*scope).ExtractFunctionArgs(tokens, depth, (*scope).LineAt(currentLine))
 
 					if err != nil {
-						return NullVariable(), 0, err.AddError(CreateError("In function \""+(*scope).Name+"\"", startLine+(*scope).Index))
+						return NullVariable(), 0, err.AddError((*scope).ContextError())
 					}
 
 					// Call the function
 
-					_, err = RunBuiltIn(command.(string), args, startLine+currentLine+(*scope).Index)
+					_, err = RunBuiltIn(scope, command.(string), args, (*scope).LineAt(currentLine))
 					if err != nil {
-						return NullVariable(), 0, err
+						return NullVariable(), 0, err.At(commandSpan).AddError((*scope).ContextError())
 					}
 
+				} else if command.(string) == "end" {
+					// The blocks consume their own "end"
+					return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_UNEXPECTED_END), (*scope).LineAt(currentLine)).At(commandSpan)
 				} else {
 					// Command is unknown
-					return NullVariable(), 0, CreateError("Error: Unknown command \""+command.(string)+"\"", startLine+currentLine+(*scope).Index)
+					hint := Message{}
+					if !IsReservedWord(command.(string)) {
+						hint = didYouMean(command.(string), append((*scope).KnownNames(), STATEMENT_KEYWORDS...))
+					}
+					return NullVariable(), 0, CreateSyntaxError(ErrorMessage(MSG_UNKNOWN_COMMAND, command.(string)), (*scope).LineAt(currentLine)).At(commandSpan).WithHint(hint)
 				}
 
 			}
@@ -3,70 +3,58 @@ package kode
 import (
 	"fmt"
 	"math"
-	"math/rand"
 	"strconv"
 	"strings"
-	"time"
 	"unicode"
+	"unicode/utf8"
 )
 
+// Names of the embedded functions of Kode
+var BUILT_IN_FUNCTIONS = []string{
+	"print", "toString", "toInt", "toFloat", "yell", "whisper", "typeOf", "len", "random", "append", "truncate",
+	"round", "sqrt", "isNumeric", "isAlphaNumeric", "toUnicode", "fromUnicode", "slice", "bytes", "graphemes",
+	"freeze", "copy", "deepCopy", "push", "pop", "insert", "removeAt", "clear", "reserve", "extend", "repr",
+	"sin", "cos", "tan", "atan2", "exp", "log", "log10", "floor", "ceil", "abs", "min", "max", "clamp", "hypot",
+	"gcd", "lcm", "isNaN", "isInf", "seed", "randInt", "choice", "shuffle", "sample", "randNormal", "randExp",
+	"split", "join", "replace", "replaceAll", "indexOf", "lastIndexOf", "contains", "startsWith", "endsWith",
+	"trim", "trimLeft", "trimRight", "padLeft", "padRight", "repeat", "reverse", "lines", "chars", "title",
+	"regex", "parseJSON", "toJSON", "readCSV", "parseCSV", "openCSV", "toCSV", "writeCSV", "readFile",
+	"writeFile", "appendFile", "readLines", "exists", "listDir", "mkdir", "remove", "stat", "pathJoin",
+	"pathBase", "pathDir", "pathExt", "input", "readLine", "readInt", "readAll", "eof", "env", "setEnv", "exit",
+	"assert", "assertEqual", "now", "unix", "monotonic", "sleep", "datetime", "fromUnix", "parseTime",
+	"formatTime", "addDays", "addSeconds", "diffSeconds", "toZone", "get", "has",
+}
+
+// Set of the embedded functions, for fast lookups
+var builtInSet = func() map[string]bool {
+	set := map[string]bool{}
+	for _, name := range BUILT_IN_FUNCTIONS {
+		set[name] = true
+	}
+	return set
+}()
+
 /**
  * Check if an embedded function exists for Kode.
  * @param name : string - The name of the function.
  * @return boolean - True if the function exists, false otherwise.
 **/
 func ExistsBuiltIn(name string) bool {
-	switch name {
-	case "print":
-		return true
-	case "toString":
-		return true
-	case "toInt":
-		return true
-	case "toFloat":
-		return true
-	case "yell":
-		return true
-	case "whisper":
-		return true
-	case "typeOf":
-		return true
-	case "len":
-		return true
-	case "random":
-		return true
-	case "append":
-		return true
-	case "truncate":
-		return true
-	case "round":
-		return true
-	case "sqrt":
-		return true
-	case "isNumeric":
-		return true
-	case "isAlphaNumeric":
-		return true
-	case "toUnicode":
-		return true
-	case "fromUnicode":
-		return true
-	default:
-		return false
-	}
+	return builtInSet[name]
 }
 
 /**
  * Run a Kode embedded function.
+ * @param scope : *Function - The scope calling the function.
  * @param name : string - The name of the function.
  * @param args :[]*Variable - The arguments to the function.
  * @return *Variable - The result of the function.
  * @return error - The error if one occurs.
 **/
-func RunBuiltIn(name string, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+func RunBuiltIn(scope *Function, name string, args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	switch name {
 	case "print":
-		return Print(args, startLine)
+		return Print(scope, args, startLine)
 	case "toString":
 		return ToString(args, startLine)
 	case "toInt":
@@ -82,11 +70,11 @@ func RunBuiltIn(name string, args []*Variable, startLine int) (*Variable, *Error
 	case "len":
 		return Len(args, startLine)
 	case "random":
-		return Random(args, startLine)
+		return Random(scope, args, startLine)
 	case "append":
 		return Append(args, startLine)
 	case "truncate":
-		return Truncate(args, startLine)
+		return Truncate(scope, args, startLine)
 	case "round":
 		return Round(args, startLine)
 	case "sqrt":
@@ -99,6 +87,212 @@ func RunBuiltIn(name string, args []*Variable, startLine int) (*Variable, *Error
 		return ToUnicode(args, startLine)
 	case "fromUnicode":
 		return FromUnicode(args, startLine)
+	case "slice":
+		return Slice(scope, args, startLine)
+	case "bytes":
+		return Bytes(args, startLine)
+	case "graphemes":
+		return Graphemes(args, startLine)
+	case "freeze":
+		return Freeze(args, startLine)
+	case "get":
+		return Get(args, startLine)
+	case "has":
+		return Has(args, startLine)
+	case "copy":
+		return Copy(args, startLine)
+	case "deepCopy":
+		return DeepCopy(args, startLine)
+	case "push":
+		return Push(args, startLine)
+	case "pop":
+		return Pop(args, startLine)
+	case "insert":
+		return Insert(scope, args, startLine)
+	case "removeAt":
+		return RemoveAt(scope, args, startLine)
+	case "clear":
+		return Clear(args, startLine)
+	case "reserve":
+		return Reserve(args, startLine)
+	case "extend":
+		return Extend(args, startLine)
+	case "repr":
+		return Repr(args, startLine)
+	case "sin":
+		return Sin(args, startLine)
+	case "cos":
+		return Cos(args, startLine)
+	case "tan":
+		return Tan(args, startLine)
+	case "atan2":
+		return Atan2(args, startLine)
+	case "exp":
+		return Exp(args, startLine)
+	case "log":
+		return Log(args, startLine)
+	case "log10":
+		return Log10(args, startLine)
+	case "floor":
+		return Floor(args, startLine)
+	case "ceil":
+		return Ceil(args, startLine)
+	case "abs":
+		return Abs(args, startLine)
+	case "min":
+		return Min(args, startLine)
+	case "max":
+		return Max(args, startLine)
+	case "clamp":
+		return Clamp(args, startLine)
+	case "hypot":
+		return Hypot(args, startLine)
+	case "gcd":
+		return Gcd(args, startLine)
+	case "lcm":
+		return Lcm(args, startLine)
+	case "isNaN":
+		return IsNaN(args, startLine)
+	case "isInf":
+		return IsInf(args, startLine)
+	case "seed":
+		return Seed(scope, args, startLine)
+	case "randInt":
+		return RandInt(scope, args, startLine)
+	case "choice":
+		return Choice(scope, args, startLine)
+	case "shuffle":
+		return Shuffle(scope, args, startLine)
+	case "sample":
+		return Sample(scope, args, startLine)
+	case "randNormal":
+		return RandNormal(scope, args, startLine)
+	case "randExp":
+		return RandExp(scope, args, startLine)
+	case "split":
+		return Split(args, startLine)
+	case "join":
+		return Join(args, startLine)
+	case "replace":
+		return Replace(args, startLine)
+	case "replaceAll":
+		return ReplaceAll(args, startLine)
+	case "indexOf":
+		return IndexOf(args, startLine)
+	case "lastIndexOf":
+		return LastIndexOf(args, startLine)
+	case "contains":
+		return Contains(args, startLine)
+	case "startsWith":
+		return StartsWith(args, startLine)
+	case "endsWith":
+		return EndsWith(args, startLine)
+	case "trim":
+		return Trim(args, startLine)
+	case "trimLeft":
+		return TrimLeft(args, startLine)
+	case "trimRight":
+		return TrimRight(args, startLine)
+	case "padLeft":
+		return PadLeft(args, startLine)
+	case "padRight":
+		return PadRight(args, startLine)
+	case "repeat":
+		return Repeat(args, startLine)
+	case "reverse":
+		return Reverse(args, startLine)
+	case "lines":
+		return Lines(args, startLine)
+	case "chars":
+		return Chars(args, startLine)
+	case "title":
+		return Title(args, startLine)
+	case "regex":
+		return Regex(args, startLine)
+	case "parseJSON":
+		return ParseJSON(args, startLine)
+	case "toJSON":
+		return ToJSON(args, startLine)
+	case "readCSV":
+		return ReadCSV(scope, args, startLine)
+	case "parseCSV":
+		return ParseCSV(args, startLine)
+	case "openCSV":
+		return OpenCSV(scope, args, startLine)
+	case "toCSV":
+		return ToCSV(args, startLine)
+	case "writeCSV":
+		return WriteCSV(scope, args, startLine)
+	case "readFile":
+		return ReadFile(scope, args, startLine)
+	case "writeFile":
+		return WriteFile(scope, args, startLine)
+	case "appendFile":
+		return AppendFile(scope, args, startLine)
+	case "readLines":
+		return ReadLines(scope, args, startLine)
+	case "exists":
+		return Exists(scope, args, startLine)
+	case "listDir":
+		return ListDir(scope, args, startLine)
+	case "mkdir":
+		return Mkdir(scope, args, startLine)
+	case "remove":
+		return Remove(scope, args, startLine)
+	case "stat":
+		return Stat(scope, args, startLine)
+	case "pathJoin":
+		return PathJoin(args, startLine)
+	case "pathBase":
+		return PathBase(args, startLine)
+	case "pathDir":
+		return PathDir(args, startLine)
+	case "pathExt":
+		return PathExt(args, startLine)
+	case "input":
+		return Input(scope, args, startLine)
+	case "readLine":
+		return ReadLine(scope, args, startLine)
+	case "readInt":
+		return ReadInt(scope, args, startLine)
+	case "readAll":
+		return ReadAll(scope, args, startLine)
+	case "eof":
+		return EOF(scope, args, startLine)
+	case "env":
+		return Env(scope, args, startLine)
+	case "setEnv":
+		return SetEnv(scope, args, startLine)
+	case "exit":
+		return Exit(args, startLine)
+	case "assert":
+		return Assert(args, startLine)
+	case "assertEqual":
+		return AssertEqual(args, startLine)
+	case "now":
+		return Now(scope, args, startLine)
+	case "unix":
+		return Unix(scope, args, startLine)
+	case "monotonic":
+		return Monotonic(scope, args, startLine)
+	case "sleep":
+		return Sleep(scope, args, startLine)
+	case "datetime":
+		return Datetime(scope, args, startLine)
+	case "fromUnix":
+		return FromUnix(scope, args, startLine)
+	case "parseTime":
+		return ParseTime(scope, args, startLine)
+	case "formatTime":
+		return FormatTime(args, startLine)
+	case "addDays":
+		return AddDays(args, startLine)
+	case "addSeconds":
+		return AddSeconds(args, startLine)
+	case "diffSeconds":
+		return DiffSeconds(args, startLine)
+	case "toZone":
+		return ToZone(args, startLine)
 	default:
 		return NullVariable(), nil
 	}
@@ -110,42 +304,13 @@ func RunBuiltIn(name string, args []*Variable, startLine int) (*Variable, *Error
  * @return *Variable - The result of the function.
  * @return error - The error if one occurs.
 **/
-func Print(args []*Variable, startLine int) (*Variable, *ErrorStack) {
-	msg := ""
+func Print(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	values := make([]string, len(args))
 	for i, arg := range args {
-
-		switch arg.Type {
-		case "string":
-			msg += arg.Value.(string)
-			break
-		case "int":
-			msg += strconv.FormatInt(arg.Value.(int64), 10)
-			break
-		case "float":
-			msg += fmt.Sprintf("%g", arg.Value.(float64))
-			break
-		case "bool":
-			msg += strconv.FormatBool(arg.Value.(bool))
-			break
-		case "null":
-			msg += "null"
-			break
-		case "function":
-			msg += "function"
-			break
-		default:
-			if isArrayType(arg.Type) {
-				msg += "array"
-			} else {
-				msg += "unknown"
-			}
-			break
-		}
-		if i != 0 || i != len(args)-1 {
-			msg += " "
-		}
+		values[i] = FormatVariable(*arg)
 	}
-	fmt.Println(msg)
+	msg := strings.Join(values, " ")
+	fmt.Fprintln(scope.GetInterpreter().Output, msg)
 	variable := CreateVariable(msg)
 	return &variable, nil
 }
@@ -157,32 +322,12 @@ func Print(args []*Variable, startLine int) (*Variable, *ErrorStack) {
  * @return error - The error if one occurs.
 **/
 func ToString(args []*Variable, startLine int) (*Variable, *ErrorStack) {
-	if len(args) == 1 {
-		switch args[0].Type {
-		case "string":
-			variable := CreateVariable(args[0].Value.(string))
-			return &variable, nil
-		case "int":
-			variable := CreateVariable(strconv.FormatInt(args[0].Value.(int64), 10))
-			return &variable, nil
-		case "float":
-			variable := CreateVariable(fmt.Sprintf("%g", args[0].Value.(float64)))
-			return &variable, nil
-		case "bool":
-			variable := CreateVariable(strconv.FormatBool(args[0].Value.(bool)))
-			return &variable, nil
-		case "null":
-			variable := CreateVariable("null")
-			return &variable, nil
-		case "function":
-			variable := CreateVariable("function")
-			return &variable, nil
-		default:
-			return NullVariable(), nil
-		}
-	} else {
-		return NullVariable(), CreateError("Error: Expected 1 argument for \"toString\"", startLine)
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "toString"), startLine)
 	}
+
+	variable := CreateVariable(FormatVariable(*args[0]))
+	return &variable, nil
 }
 
 /**
@@ -193,14 +338,14 @@ func ToString(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 **/
 func ToInt(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 argument for \"toInt\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "toInt"), startLine)
 	}
 
 	switch args[0].Type {
 	case "string":
 		i, err := strconv.ParseInt(args[0].Value.(string), 10, 64)
 		if err != nil {
-			return NullVariable(), CreateError("Error: String is not a number or is too large to be converted to an int for \"toInt\"", startLine)
+			return NullVariable(), CreateError(ErrorMessage(MSG_NOT_A_NUMBER, "an int", "toInt"), startLine)
 		}
 		variable := CreateVariable(i)
 		return &variable, nil
@@ -212,7 +357,7 @@ func ToInt(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 		variable := CreateVariable(i)
 		return &variable, nil
 	default:
-		return NullVariable(), CreateError("Error: Argument must be a string or a number for \"toInt\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string or a number", "toInt"), startLine)
 	}
 
 }
@@ -225,14 +370,14 @@ func ToInt(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 **/
 func ToFloat(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 argument for \"toFloat\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "toFloat"), startLine)
 	}
 
 	switch args[0].Type {
 	case "string":
 		f, err := strconv.ParseFloat(args[0].Value.(string), 64)
 		if err != nil {
-			return NullVariable(), CreateError("Error: String is not a number or is too large to be converted to a float for \"toFloat\"", startLine)
+			return NullVariable(), CreateError(ErrorMessage(MSG_NOT_A_NUMBER, "a float", "toFloat"), startLine)
 		}
 		variable := CreateVariable(f)
 		return &variable, nil
@@ -244,7 +389,7 @@ func ToFloat(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 		variable := CreateVariable(args[0].Value.(float64))
 		return &variable, nil
 	default:
-		return NullVariable(), CreateError("Error: Argument must be a string or a number for \"toFloat\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string or a number", "toFloat"), startLine)
 	}
 }
 
@@ -257,11 +402,11 @@ func ToFloat(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 func Whisper(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 string argument for \"whisper\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_STRING, "whisper"), startLine)
 	}
 
 	if args[0].Type != "string" {
-		return NullVariable(), CreateError("Error: Argument must be a string for \"whisper\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string", "whisper"), startLine)
 	}
 
 	// Lowercase the string
@@ -279,11 +424,11 @@ func Whisper(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 func Yell(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 string argument for \"yell\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_STRING, "yell"), startLine)
 	}
 
 	if args[0].Type != "string" {
-		return NullVariable(), CreateError("Error: Argument must be a string for \"yell\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string", "yell"), startLine)
 	}
 
 	// Uppercase the string
@@ -300,7 +445,7 @@ func Yell(args []*Variable, startLine int) (*Variable, *ErrorStack) {
  */
 func TypeOf(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 argument for \"typeOf\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "typeOf"), startLine)
 	}
 
 	variable := CreateVariable(args[0].Type)
@@ -309,78 +454,61 @@ func TypeOf(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 
 func Len(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 argument for \"len\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "len"), startLine)
 	}
 
 	if !isArrayType(args[0].Type) && args[0].Type != "string" {
-		return NullVariable(), CreateError("Error: Argument must be an array or a string for \"len\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "an array or a string", "len"), startLine)
 	}
 
 	if args[0].Type == "string" {
-		variable := CreateVariable(int64(len(args[0].Value.(string))))
+		// Count characters (runes) rather than bytes
+		variable := CreateVariable(int64(utf8.RuneCountInString(args[0].Value.(string))))
 		return &variable, nil
 	} else {
-		variable := CreateVariable(int64(len(args[0].Value.([]Variable))))
+		variable := CreateVariable(int64(len(args[0].Elements())))
 		return &variable, nil
 	}
 }
 
-func Random(args []*Variable, startLine int) (*Variable, *ErrorStack) {
-	if len(args) > 0 {
-		return NullVariable(), CreateError("Error: Expected 0 arguments for \"random\"", startLine)
-	}
-
-	rand.Seed(time.Now().UnixNano())
-	variable := CreateVariable(rand.Float64())
-	return &variable, nil
-}
-
 func Append(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 
 	if len(args) != 2 {
-		return NullVariable(), CreateError("Error: Expected 2 arguments for \"append\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", "append"), startLine)
 	}
 
 	if !isArrayType(args[0].Type) {
-		return NullVariable(), CreateError("Error: Argument 1 must be an array for \"append\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "an array", "append"), startLine)
 	}
 
-	// Get allowed types
-	allowedType := strings.ReplaceAll(args[0].Type, "[]", "")
-
-	if allowedType != "val" {
-		if args[1].Type != allowedType {
-			return NullVariable(), CreateError("Error: Argument 2 must be a "+allowedType+" for \"append\"", startLine)
-		}
+	// Check the type of the new element
+	err := CheckArrayElement(args[0], args[1], "append", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
 
-		// Append the value
-		array := append(args[0].Value.([]Variable), *(args[1]))
+	// Append the value to a new array so the result never shares storage with the argument
+	array := make([]Variable, len(args[0].Elements()), len(args[0].Elements())+1)
+	copy(array, args[0].Elements())
+	array = append(array, *(args[1]))
 
-		// Return the array
-		variable := CreateVariable(array)
-		return &variable, nil
-	} else {
-		// Append the value
-		array := append(args[0].Value.([]Variable), *(args[1]))
-
-		// Return the array
-		variable := CreateVariable(array)
-		return &variable, nil
-	}
+	// Return the array
+	variable := CreateVariable(array)
+	return &variable, nil
 
 }
 
-func Truncate(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+func Truncate(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	if len(args) != 2 {
-		return NullVariable(), CreateError("Error: Expected 2 arguments for \"truncate\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", "truncate"), startLine)
 	}
 
 	if !isArrayType(args[0].Type) {
-		return NullVariable(), CreateError("Error: Argument 1 must be an array for \"truncate\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "an array", "truncate"), startLine)
 	}
 
 	if args[1].Type != "int" {
-		return NullVariable(), CreateError("Error: Argument 2 must be an int for \"truncate\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "an int", "truncate"), startLine)
 	}
 
 	// Get size and index
@@ -390,9 +518,9 @@ func Truncate(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 		return NullVariable(), err
 	}
 
-	index := args[1].Value.(int64) % size
-	if index < 0 { // Handle negative indexes
-		index += size
+	index, err := (*scope).ResolveIndex(args[1].Value.(int64), size, startLine)
+	if err != nil {
+		return NullVariable(), err
 	}
 
 	// Truncate the array
@@ -406,9 +534,9 @@ func Truncate(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 
 		if i != index || removed {
 			if removed {
-				newArray[i] = array.Value.([]Variable)[i+1]
+				newArray[i] = array.Elements()[i+1]
 			} else {
-				newArray[i] = array.Value.([]Variable)[i]
+				newArray[i] = array.Elements()[i]
 			}
 			i++
 		} else {
@@ -423,11 +551,17 @@ func Truncate(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 
 func Round(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 argument for \"round\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "round"), startLine)
+	}
+
+	// Ints are already rounded
+	if args[0].Type == "int" {
+		variable := CreateVariable(args[0].Value.(int64))
+		return &variable, nil
 	}
 
 	if args[0].Type != "float" {
-		return NullVariable(), CreateError("Error: Argument must be a float for \"round\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a float or an int", "round"), startLine)
 	}
 
 	// Round the float
@@ -438,11 +572,11 @@ func Round(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 
 func Sqrt(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 argument for \"sqrt\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "sqrt"), startLine)
 	}
 
 	if args[0].Type != "float" && args[0].Type != "int" {
-		return NullVariable(), CreateError("Error: Argument must be a float or an int for \"sqrt\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a float or an int", "sqrt"), startLine)
 	}
 
 	// Square root the float
@@ -450,7 +584,7 @@ func Sqrt(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 		f := args[0].Value.(float64)
 
 		if f < 0 {
-			return NullVariable(), CreateError("Error: Argument must be a positive float for \"sqrt\"", startLine)
+			return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a positive float", "sqrt"), startLine)
 		}
 
 		variable := CreateVariable(math.Sqrt(f))
@@ -459,7 +593,7 @@ func Sqrt(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 		i := args[0].Value.(int64)
 
 		if i < 0 {
-			return NullVariable(), CreateError("Error: Argument must be a positive int for \"sqrt\"", startLine)
+			return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a positive int", "sqrt"), startLine)
 		}
 
 		variable := CreateVariable(math.Sqrt(float64(i)))
@@ -469,11 +603,11 @@ func Sqrt(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 
 func IsNumeric(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 argument for \"isNumeric\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "isNumeric"), startLine)
 	}
 
 	if args[0].Type != "string" {
-		return NullVariable(), CreateError("Error: Argument must be a string for \"isNumeric\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string", "isNumeric"), startLine)
 	}
 
 	// Check if the string is numeric
@@ -490,11 +624,11 @@ func IsNumeric(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 
 func IsAlphaNumeric(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 argument for \"isAlphaNumeric\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "isAlphaNumeric"), startLine)
 	}
 
 	if args[0].Type != "string" {
-		return NullVariable(), CreateError("Error: Argument must be a string for \"isAlphaNumeric\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string", "isAlphaNumeric"), startLine)
 	}
 
 	for _, c := range args[0].Value.(string) {
@@ -511,32 +645,540 @@ func IsAlphaNumeric(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 
 func ToUnicode(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 argument for \"toUnicode\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "toUnicode"), startLine)
 	}
 
 	if args[0].Type != "string" {
-		return NullVariable(), CreateError("Error: Argument must be a string for \"toUnicode\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string", "toUnicode"), startLine)
 	}
 
-	if len(args[0].Value.(string)) != 1 {
-		return NullVariable(), CreateError("Error: String argument must be of size 1 for \"toUnicode\"", startLine)
+	if utf8.RuneCountInString(args[0].Value.(string)) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_SINGLE_CHARACTER, "toUnicode"), startLine)
 	}
 
-	// Convert the string to unicode
-	variable := CreateVariable(int64(args[0].Value.(string)[0]))
+	// Convert the character to its code point
+	r, _ := utf8.DecodeRuneInString(args[0].Value.(string))
+	variable := CreateVariable(int64(r))
 	return &variable, nil
 }
 
 func FromUnicode(args []*Variable, startLine int) (*Variable, *ErrorStack) {
 	if len(args) != 1 {
-		return NullVariable(), CreateError("Error: Expected 1 argument for \"fromUnicode\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "fromUnicode"), startLine)
 	}
 
 	if args[0].Type != "int" {
-		return NullVariable(), CreateError("Error: Argument must be an integer for \"fromUnicode\"", startLine)
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "an integer", "fromUnicode"), startLine)
+	}
+
+	code := args[0].Value.(int64)
+	if code < 0 || code > utf8.MaxRune || !utf8.ValidRune(rune(code)) {
+		return NullVariable(), CreateError(ErrorMessage(MSG_CODE_POINT, strconv.FormatInt(code, 10), "fromUnicode"), startLine)
+	}
+
+	// Convert the code point to string
+	variable := CreateVariable(string(rune(code)))
+	return &variable, nil
+}
+
+/**
+ * Get the part of a string or an array between two indexes. The end index is excluded.
+ * Strings are sliced by characters (runes). Negative indexes count from the end.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Slice(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 && len(args) != 3 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2 or 3", "slice"), startLine)
+	}
+
+	if !isArrayType(args[0].Type) && args[0].Type != "string" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "an array or a string", "slice"), startLine)
+	}
+
+	for _, arg := range args[1:] {
+		if arg.Type != "int" {
+			return NullVariable(), CreateError(ErrorMessage(MSG_INDEXES_INT, "slice"), startLine)
+		}
+	}
+
+	size, err := GetArraySize(args[0], startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	// Negative indexes count from the end
+	start := args[1].Value.(int64)
+	if start < 0 {
+		start += size
+	}
+	end := size
+	if len(args) == 3 {
+		end = args[2].Value.(int64)
+		if end < 0 {
+			end += size
+		}
+	}
+
+	if start < 0 || end > size || start > end {
+		return NullVariable(), CreateError(ErrorMessage(MSG_INVALID_RANGE, strconv.FormatInt(start, 10), strconv.FormatInt(end, 10), strconv.FormatInt(size, 10), "slice"), startLine)
+	}
+
+	if args[0].Type == "string" {
+		variable := CreateVariable(string([]rune(args[0].Value.(string))[start:end]))
+		return &variable, nil
+	}
+
+	// Copy the elements to avoid sharing the original array
+	array := make([]Variable, end-start)
+	copy(array, args[0].Elements()[start:end])
+	variable := Variable{Value: &Array{Elements: array}, Type: args[0].Type}
+	return &variable, nil
+}
+
+/**
+ * Get the UTF-8 bytes of a string.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Bytes(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "bytes"), startLine)
+	}
+
+	if args[0].Type != "string" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string", "bytes"), startLine)
+	}
+
+	str := args[0].Value.(string)
+	array := make([]Variable, len(str))
+	for i := 0; i < len(str); i++ {
+		array[i] = CreateVariable(int64(str[i]))
+	}
+
+	variable := Variable{Value: &Array{Elements: array}, Type: "int[]"}
+	return &variable, nil
+}
+
+/**
+ * Split a string into user-perceived characters (graphemes), e.g. a letter followed by its combining accents.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Graphemes(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "graphemes"), startLine)
+	}
+
+	if args[0].Type != "string" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string", "graphemes"), startLine)
+	}
+
+	clusters := SplitGraphemes(args[0].Value.(string))
+	array := make([]Variable, len(clusters))
+	for i, cluster := range clusters {
+		array[i] = CreateVariable(cluster)
+	}
+
+	variable := Variable{Value: &Array{Elements: array}, Type: "string[]"}
+	return &variable, nil
+}
+
+/**
+ * Deep-freeze an array or an object in place and return it. Its elements or fields, and the ones of its nested arrays
+ * and objects, cannot be modified anymore through any variable holding it, even by the methods of the object (use
+ * "copy" or "deepCopy" to get a modifiable value).
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Freeze(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "freeze"), startLine)
+	}
+
+	FreezeStorage(*args[0])
+	variable := *args[0]
+	variable.Constant = false
+	return &variable, nil
+}
+
+/**
+ * Copy an array or an object. Nested arrays and objects are still shared with the original.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Copy(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "copy"), startLine)
+	}
+
+	variable := CopyValue(*args[0], false)
+	return &variable, nil
+}
+
+/**
+ * Copy an array or an object along with every nested array and object.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func DeepCopy(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "deepCopy"), startLine)
+	}
+
+	variable := CopyValue(*args[0], true)
+	return &variable, nil
+}
+
+/**
+ * Get a field of an object by its name, e.g. get(user, "e-mail") for the keys of a JSON object or the columns of a
+ * CSV record that are not valid names.
+ * @param args :[]*Variable - The object and the name of the field.
+ * @return *Variable - The field.
+ * @return error - The error if one occurs (e.g. the object has no such field).
+**/
+func Get(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	instance, name, err := fieldArguments(args, "get", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	field, exists := instance.Variables[name]
+	if !exists || field == nil || instance.isSystemVariable(name) {
+		return NullVariable(), CreateError(ErrorMessage(MSG_UNKNOWN_FIELD, name), startLine)
+	}
+	variable := *field
+	return &variable, nil
+}
+
+/**
+ * Check if an object has a field, e.g. has(user, "e-mail").
+ * @param args :[]*Variable - The object and the name of the field.
+ * @return *Variable - True if the object has the field.
+ * @return error - The error if one occurs.
+**/
+func Has(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	instance, name, err := fieldArguments(args, "has", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	field, exists := instance.Variables[name]
+	variable := CreateVariable(exists && field != nil && !instance.isSystemVariable(name))
+	return &variable, nil
+}
+
+/**
+ * Check the arguments of the functions reading a field by its name.
+ * @param args :[]*Variable - The object and the name of the field.
+ * @param name : string - The name of the calling function.
+ * @return Function - The object.
+ * @return string - The name of the field.
+ * @return error - The error if one occurs.
+**/
+func fieldArguments(args []*Variable, name string, startLine int) (Function, string, *ErrorStack) {
+	if len(args) != 2 {
+		return Function{}, "", CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", name), startLine)
+	}
+	instance, isObject := args[0].Value.(Function)
+	if !isObject {
+		return Function{}, "", CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "an object", name), startLine)
+	}
+	if args[1].Type != "string" {
+		return Function{}, "", CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "a string", name), startLine)
+	}
+	return instance, args[1].Value.(string), nil
+}
+
+/**
+ * Check if an array can be modified in place and if an element is allowed inside a typed array.
+ * @param array : *Variable - The array to modify.
+ * @param element : *Variable - The element to add (nil if no element is added).
+ * @param name : string - The name of the calling function.
+ * @return error - The error if one occurs.
+**/
+func CheckArrayElement(array *Variable, element *Variable, name string, startLine int) *ErrorStack {
+	if !isArrayType(array.Type) {
+		return CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "an array", name), startLine)
+	}
+
+	if element == nil {
+		return nil
+	}
+
+	// Typed arrays only accept elements of their type
+	// e.g. int[] accepts int and int[][] accepts int[] (including empty arrays)
+	allowedType := strings.TrimSuffix(array.Type, "[]")
+	if allowedType != "val" && element.Type != allowedType && !(isArrayType(allowedType) && element.Type == "val[]" && len(element.Elements()) == 0) {
+		return CreateError(ErrorMessage(MSG_ELEMENT_TYPE, allowedType, element.Type, name), startLine)
+	}
+
+	return nil
+}
+
+/**
+ * Check if an array can be modified in place.
+ * @param array : *Variable - The array to modify.
+ * @param name : string - The name of the calling function.
+ * @return error - The error if one occurs.
+**/
+func checkMutableArray(array *Variable, name string, startLine int) *ErrorStack {
+	if !isArrayType(array.Type) {
+		return CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "an array", name), startLine)
+	}
+
+	if array.IsFrozen() {
+		return CreateError(ErrorMessage(MSG_FROZEN_ARRAY, name), startLine)
+	}
+
+	return nil
+}
+
+/**
+ * Add elements at the end of an array in place.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The new size of the array.
+ * @return error - The error if one occurs.
+**/
+func Push(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) < 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_AT_LEAST_ARGS, "2", "push"), startLine)
+	}
+
+	err := checkMutableArray(args[0], "push", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	for _, element := range args[1:] {
+		err := CheckArrayElement(args[0], element, "push", startLine)
+		if err != nil {
+			return NullVariable(), err
+		}
+	}
+
+	// Amortized growth of the shared storage
+	array := args[0].Value.(*Array)
+	for _, element := range args[1:] {
+		array.Elements = append(array.Elements, *element)
+	}
+
+	variable := CreateVariable(int64(len(array.Elements)))
+	return &variable, nil
+}
+
+/**
+ * Remove the last element of an array in place.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The removed element.
+ * @return error - The error if one occurs.
+**/
+func Pop(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "pop"), startLine)
+	}
+
+	err := checkMutableArray(args[0], "pop", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	array := args[0].Value.(*Array)
+	size := len(array.Elements)
+	if size == 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_POP_EMPTY), startLine)
+	}
+
+	element := array.Elements[size-1]
+	array.Elements[size-1] = Variable{} // Release the reference
+	array.Elements = array.Elements[:size-1]
+
+	return &element, nil
+}
+
+/**
+ * Insert an element at an index of an array in place. Negative indexes count from the end, -1 being after the last element.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The new size of the array.
+ * @return error - The error if one occurs.
+**/
+func Insert(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 3 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "3", "insert"), startLine)
+	}
+
+	err := checkMutableArray(args[0], "insert", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	if args[1].Type != "int" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "an int", "insert"), startLine)
+	}
+
+	err = CheckArrayElement(args[0], args[2], "insert", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	// The element can be inserted at any index up to the size of the array
+	array := args[0].Value.(*Array)
+	index, err := (*scope).ResolveIndex(args[1].Value.(int64), int64(len(array.Elements))+1, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	array.Elements = append(array.Elements, Variable{})
+	copy(array.Elements[index+1:], array.Elements[index:])
+	array.Elements[index] = *args[2]
+
+	variable := CreateVariable(int64(len(array.Elements)))
+	return &variable, nil
+}
+
+/**
+ * Remove the element at an index of an array in place.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The removed element.
+ * @return error - The error if one occurs.
+**/
+func RemoveAt(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", "removeAt"), startLine)
+	}
+
+	err := checkMutableArray(args[0], "removeAt", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	if args[1].Type != "int" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "an int", "removeAt"), startLine)
+	}
+
+	array := args[0].Value.(*Array)
+	index, err := (*scope).ResolveIndex(args[1].Value.(int64), int64(len(array.Elements)), startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	element := array.Elements[index]
+	copy(array.Elements[index:], array.Elements[index+1:])
+	array.Elements[len(array.Elements)-1] = Variable{} // Release the reference
+	array.Elements = array.Elements[:len(array.Elements)-1]
+
+	return &element, nil
+}
+
+/**
+ * Remove all the elements of an array in place. The capacity is kept for future elements.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - Null.
+ * @return error - The error if one occurs.
+**/
+func Clear(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "clear"), startLine)
+	}
+
+	err := checkMutableArray(args[0], "clear", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	array := args[0].Value.(*Array)
+	for i := range array.Elements {
+		array.Elements[i] = Variable{} // Release the references
+	}
+	array.Elements = array.Elements[:0]
+
+	return NullVariable(), nil
+}
+
+/**
+ * Make room for at least n elements in an array so the next additions do not reallocate.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - Null.
+ * @return error - The error if one occurs.
+**/
+func Reserve(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", "reserve"), startLine)
+	}
+
+	err := checkMutableArray(args[0], "reserve", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	if args[1].Type != "int" || args[1].Value.(int64) < 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "a positive int", "reserve"), startLine)
+	}
+
+	array := args[0].Value.(*Array)
+	capacity := int(args[1].Value.(int64))
+	if cap(array.Elements) < capacity {
+		elements := make([]Variable, len(array.Elements), capacity)
+		copy(elements, array.Elements)
+		array.Elements = elements
+	}
+
+	return NullVariable(), nil
+}
+
+/**
+ * Add every element of an array at the end of another array in place.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The new size of the array.
+ * @return error - The error if one occurs.
+**/
+func Extend(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", "extend"), startLine)
+	}
+
+	err := checkMutableArray(args[0], "extend", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	if !isArrayType(args[1].Type) {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "an array", "extend"), startLine)
+	}
+
+	// Copy the elements first in case the array is extended with itself
+	elements := append([]Variable{}, args[1].Elements()...)
+	for i := range elements {
+		err := CheckArrayElement(args[0], &elements[i], "extend", startLine)
+		if err != nil {
+			return NullVariable(), err
+		}
+	}
+
+	array := args[0].Value.(*Array)
+	array.Elements = append(array.Elements, elements...)
+
+	variable := CreateVariable(int64(len(array.Elements)))
+	return &variable, nil
+}
+
+/**
+ * Get the representation of a variable as Kode code. Data values parse back to an equal value.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Repr(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "repr"), startLine)
 	}
 
-	// Convert the unicode to string
-	variable := CreateVariable(string(rune(args[0].Value.(int64))))
+	variable := CreateVariable(ReprVariable(*args[0]))
 	return &variable, nil
 }
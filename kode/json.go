@@ -0,0 +1,255 @@
+package kode
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * Parse a JSON string. Arrays become Kode arrays, objects become Kode objects and numbers become ints
+ * unless they have a decimal point or an exponent (like number literals in Kode). The keys of the objects become
+ * fields; the keys that are not valid names (e.g. "e-f" or "2") are read with "get".
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs, with the line and column in the JSON string.
+**/
+func ParseJSON(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "parseJSON"), startLine)
+	}
+
+	if args[0].Type != "string" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string", "parseJSON"), startLine)
+	}
+
+	str := args[0].Value.(string)
+	decoder := json.NewDecoder(strings.NewReader(str))
+	decoder.UseNumber()
+
+	variable, err := decodeJSONValue(decoder)
+	if err == nil {
+		// Only whitespace is allowed after the value
+		if _, tokenErr := decoder.Token(); tokenErr != io.EOF {
+			err = errors.New("unexpected data after the JSON value")
+		}
+	}
+
+	if err != nil {
+		// Locate the error in the JSON string
+		offset := decoder.InputOffset()
+		var syntaxError *json.SyntaxError
+		if errors.As(err, &syntaxError) {
+			offset = syntaxError.Offset
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = errors.New("unexpected end of JSON input")
+			offset = int64(len(str))
+		}
+		line, column := offsetToLineColumn(str, offset)
+		return NullVariable(), CreateError(ErrorMessage(MSG_INVALID_JSON, strconv.Itoa(line), strconv.Itoa(column), err.Error(), "parseJSON"), startLine)
+	}
+
+	return &variable, nil
+}
+
+/**
+ * Decode the next JSON value of a decoder.
+ * @param decoder : *json.Decoder - The decoder.
+ * @return Variable - The decoded value.
+ * @return error - The error if one occurs.
+ */
+func decodeJSONValue(decoder *json.Decoder) (Variable, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return Variable{}, err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		if value == '[' {
+			array := []Variable{}
+			for decoder.More() {
+				element, err := decodeJSONValue(decoder)
+				if err != nil {
+					return Variable{}, err
+				}
+				array = append(array, element)
+			}
+			_, err := decoder.Token() // Closing bracket
+			if err != nil {
+				return Variable{}, err
+			}
+			return CreateVariable(array), nil
+		}
+
+		// Object
+		fields := map[string]*Variable{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return Variable{}, err
+			}
+			field, err := decodeJSONValue(decoder)
+			if err != nil {
+				return Variable{}, err
+			}
+			fields[key.(string)] = &field
+		}
+		_, err := decoder.Token() // Closing brace
+		if err != nil {
+			return Variable{}, err
+		}
+		return CreateObject("object", fields), nil
+
+	case json.Number:
+		return parseJSONNumber(string(value)), nil
+	case string, bool:
+		return CreateVariable(value), nil
+	default:
+		return CreateVariable(nil), nil
+	}
+}
+
+/**
+ * Convert a JSON number to an int, or to a float if it has a decimal point, an exponent or does not fit in an int.
+ * @param number : string - The JSON number.
+ * @return Variable - The int or float.
+ */
+func parseJSONNumber(number string) Variable {
+	if !strings.ContainsAny(number, ".eE") {
+		if i, err := strconv.ParseInt(number, 10, 64); err == nil {
+			return CreateVariable(i)
+		}
+	}
+	f, _ := strconv.ParseFloat(number, 64)
+	return CreateVariable(f)
+}
+
+/**
+ * Convert a byte offset of a string into a line and a column (both starting at 1).
+ * @param str : string - The string.
+ * @param offset : int64 - The byte offset.
+ * @return int - The line.
+ * @return int - The column.
+ */
+func offsetToLineColumn(str string, offset int64) (int, int) {
+	if offset > int64(len(str)) {
+		offset = int64(len(str))
+	}
+	before := str[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := len([]rune(before[strings.LastIndex(before, "\n")+1:])) + 1
+	return line, column
+}
+
+/**
+ * Convert a value to a JSON string. Objects become JSON objects with their fields (methods are ignored).
+ * @param args :[]*Variable - The value and, optionally, the indentation as a number of spaces or a string.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func ToJSON(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 && len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "1 or 2", "toJSON"), startLine)
+	}
+
+	indent := ""
+	if len(args) == 2 {
+		switch args[1].Type {
+		case "int":
+			if args[1].Value.(int64) < 0 {
+				return NullVariable(), CreateError(ErrorMessage(MSG_POSITIVE_INDENT, "toJSON"), startLine)
+			}
+			indent = strings.Repeat(" ", int(args[1].Value.(int64)))
+		case "string":
+			indent = args[1].Value.(string)
+		default:
+			return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "an int or a string", "toJSON"), startLine)
+		}
+	}
+
+	value, err := toJSONValue(*args[0], map[interface{}]bool{})
+	if err != nil {
+		return NullVariable(), CreateError(ErrorMessage(MSG_FAILED, err.Error(), "toJSON"), startLine)
+	}
+
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(value); err != nil {
+		return NullVariable(), CreateError(ErrorMessage(MSG_FAILED, err.Error(), "toJSON"), startLine)
+	}
+
+	variable := CreateVariable(strings.TrimSuffix(buffer.String(), "\n"))
+	return &variable, nil
+}
+
+/**
+ * Convert a variable into a value encodable by encoding/json.
+ * @param variable : Variable - The variable to convert.
+ * @param visited : map[interface{}]bool - The arrays and objects being converted, used to detect cycles.
+ * @return interface{} - The encodable value.
+ * @return error - The error if one occurs.
+ */
+func toJSONValue(variable Variable, visited map[interface{}]bool) (interface{}, error) {
+	switch value := variable.Value.(type) {
+	case nil, string, bool, int64:
+		return value, nil
+	case float64:
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return nil, errors.New("Cannot convert " + FormatVariable(variable) + " to JSON")
+		}
+		// Keep the decimal point so the number is parsed back as a float
+		return json.Number(FormatFloatLiteral(value)), nil
+	case time.Time:
+		formatted, _ := formatTimeLayout(value, "iso")
+		return formatted, nil
+	case *Array:
+		if visited[value] {
+			return nil, errors.New("Cannot convert an array containing itself to JSON")
+		}
+		visited[value] = true
+		defer delete(visited, value)
+
+		array := make([]interface{}, len(value.Elements))
+		for i, element := range value.Elements {
+			converted, err := toJSONValue(element, visited)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = converted
+		}
+		return array, nil
+	case Function:
+		if !IsInstance(value) {
+			return nil, errors.New("Cannot convert the function \"" + value.Name + "\" to JSON")
+		}
+
+		key := variableMapKey(value.Variables)
+		if visited[key] {
+			return nil, errors.New("Cannot convert an object containing itself to JSON")
+		}
+		visited[key] = true
+		defer delete(visited, key)
+
+		// Map keys are sorted by encoding/json
+		object := map[string]interface{}{}
+		for _, name := range ObjectFields(value) {
+			converted, err := toJSONValue(*value.Variables[name], visited)
+			if err != nil {
+				return nil, err
+			}
+			object[name] = converted
+		}
+		return object, nil
+	default:
+		return nil, errors.New("Cannot convert a value of type (" + variable.Type + ") to JSON")
+	}
+}
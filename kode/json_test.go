@@ -0,0 +1,18 @@
+package kode
+
+import "testing"
+
+func TestJSONKeysAreNotNames(t *testing.T) {
+	code := `val o = parseJSON("{\"for\": 1, \"self\": 2, \"2\": 3, \"my-key\": [4], \"x\": 5}")
+print(get(o, "my-key"), get(o, "for"), get(o, "self"), get(o, "2"), o.x)
+print(has(o, "2"), has(o, "y"))
+print(toJSON(o))`
+	expected := "[4] 1 2 3 5\ntrue false\n{\"2\":3,\"for\":1,\"my-key\":[4],\"self\":2,\"x\":5}\n"
+	if output := runOutput(t, code); output != expected {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+
+	if code := runError(t, `get(parseJSON("{}"), "x")`).ErrorCode(); code != E_UNKNOWN_NAME {
+		t.Errorf("expected %s for a missing field, got %s", E_UNKNOWN_NAME, code)
+	}
+}
@@ -0,0 +1,126 @@
+package kode
+
+import "strings"
+
+// Pragma choosing the language of the keywords of a program, e.g. "#pragma keywords fr" to write "si" for "if"
+const KEYWORDS_PRAGMA = "pragma keywords"
+
+// Keywords of the languages other than English, by language then by keyword (e.g. "fin si" for "end if")
+var KEYWORD_ALIASES = map[string]map[string]string{
+	"fr": {
+		"si":        "if",
+		"sinon":     "else",
+		"fin":       "end",
+		"pour":      "for",
+		"dans":      "in",
+		"sortir":    "break",
+		"fonction":  "func",
+		"retourner": "return",
+		"essayer":   "try",
+		"attraper":  "catch",
+		"vrai":      "true",
+		"faux":      "false",
+		"nul":       "null",
+		"et":        "and",
+		"ou":        "or",
+		"non":       "not",
+	},
+}
+
+/**
+ * Find the language of the keywords chosen by the pragma of the code. The pragma is a comment before the first line
+ * of code, e.g. "#pragma keywords fr".
+ * @param code : string - The code.
+ * @return string - The language of the pragma, or an empty string if the code has none.
+ * @return int - The line of the pragma.
+ */
+func keywordsPragma(code string) (string, int) {
+	for i, line := range strings.Split(code, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		if fields := strings.Fields(strings.TrimPrefix(line, "#")); len(fields) == 3 && strings.Join(fields[:2], " ") == KEYWORDS_PRAGMA {
+			return fields[2], i + 1
+		}
+	}
+	return "", 0
+}
+
+/**
+ * Get the keywords of code: the ones chosen by its pragma, or else the default ones.
+ * @param code : string - The code.
+ * @param language : string - The language of the keywords if the code has no pragma (empty for English).
+ * @return map[string]string - The English keyword of each alias, or nil for the English keywords.
+ */
+func codeKeywords(code string, language string) map[string]string {
+	if pragma, _ := keywordsPragma(code); pragma != "" {
+		language = pragma
+	}
+	return KEYWORD_ALIASES[language]
+}
+
+/**
+ * Replace the aliases of the keywords of a line by the English keywords (e.g. "fin si" by "end if"). Strings,
+ * comments and the fields of objects (e.g. "point.fin") are kept as is.
+ * @param line : string - The line of code.
+ * @param aliases : map[string]string - The English keyword of each alias (see KEYWORD_ALIASES).
+ * @return string - The line with English keywords.
+ */
+func translateKeywords(line string, aliases map[string]string) string {
+	translated, _ := translateKeywordOffsets(line, aliases)
+	return translated
+}
+
+/**
+ * Replace the aliases of the keywords of a line (see translateKeywords) and keep where each byte of the translated
+ * line comes from, so that the errors found in the translated line point at the code of the original line.
+ * @param line : string - The line of code.
+ * @param aliases : map[string]string - The English keyword of each alias (see KEYWORD_ALIASES).
+ * @return string - The line with English keywords.
+ * @return []int - The offset in the original line of each byte offset of the translated line (and of its end).
+ */
+func translateKeywordOffsets(line string, aliases map[string]string) (string, []int) {
+	offsets := make([]int, 0, len(line)+1)
+	if len(aliases) == 0 {
+		for i := 0; i <= len(line); i++ {
+			offsets = append(offsets, i)
+		}
+		return line, offsets
+	}
+
+	inString := stringRanges(line)
+	builder := strings.Builder{}
+	for i := 0; i < len(line); {
+		if !isWordByte(line[i]) || inString(i) {
+			builder.WriteByte(line[i])
+			offsets = append(offsets, i)
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(line) && isWordByte(line[end]) {
+			end++
+		}
+		word := line[i:end]
+		if keyword, found := aliases[word]; found && !strings.HasSuffix(strings.TrimRight(line[:i], " \t"), ".") {
+			word = keyword
+		}
+		builder.WriteString(word)
+
+		// The bytes of a translated keyword come from the start of its alias
+		for j := 0; j < len(word); j++ {
+			if len(word) == end-i {
+				offsets = append(offsets, i+j)
+			} else {
+				offsets = append(offsets, i)
+			}
+		}
+		i = end
+	}
+	return builder.String(), append(offsets, len(line))
+}
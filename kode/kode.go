@@ -1,31 +1,363 @@
 package kode
 
 import (
-	"errors"
+	"bufio"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Variables defined by the interpreter in the main scope to configure a program (or to pass it its arguments).
+var SYSTEM_VARIABLES = []string{"_DEBUG", "_MAX_RECURSION", "_STRICT_INDEX", "args"}
+
+// ! Interpreter : The state shared by every scope of a running program.
+// -------------------------
+// ! Random : The random number generator of the program.
+// -------------------------
+// ! Methods : The methods callable on values by type (see RegisterMethod).
+// -------------------------
+// ! Permissions : The sandbox of the program (see Permissions).
+// -------------------------
+// ! Input : The stream read by "input", "readLine", etc. (see SetInput).
+// -------------------------
+// ! Output : The stream written by "print" and "input".
+// -------------------------
+// ! Args : The command-line arguments of the program (the "args" array).
+// -------------------------
+// ! Debug : The initial value of _DEBUG.
+// -------------------------
+// ! MaxRecursion : The initial value of _MAX_RECURSION.
+// -------------------------
+// ! Clock : The source of time of "now", "sleep", etc. (e.g. a FakeClock for deterministic tests).
+// -------------------------
+// ! File : The name of the file of the program, shown in the errors (empty if the code does not come from a file).
+// -------------------------
+// ! MaxTraceFrames : The maximum number of frames in the call stack of an error (0 for no limit).
+// -------------------------
+// ! TraceArguments : True to show the arguments of the calls in the call stack of an error.
+// -------------------------
+// ! Language : The language of the error messages (see LANGUAGES), English if empty.
+// -------------------------
+// ! source : The lines of the code being run, used to find the columns of the errors.
+// -------------------------
+// ! calls : The function calls being run (see CallStack).
+// -------------------------
+// ! keywords : The language of the keywords chosen by the pragma of the last code run (see KEYWORD_ALIASES).
+// -------------------------
+// ! system : The system variables of the main scope, to tell them apart from fields with the same names.
+type Interpreter struct {
+	Random         *rand.Rand
+	Methods        map[string]map[string]Method
+	Permissions    Permissions
+	Input          *bufio.Reader
+	Output         io.Writer
+	Args           []string
+	Debug          bool
+	MaxRecursion   int64
+	Clock          Clock
+	File           string
+	MaxTraceFrames int
+	TraceArguments bool
+	Language       string
+	source         []string
+	calls          []callFrame
+	keywords       string
+	system         map[string]*Variable
+}
+
+// ! Permissions : What a program is allowed to access.
+// -------------------------
+// ! Read : Read files and list directories.
+// -------------------------
+// ! Write : Create, modify and remove files and directories.
+// -------------------------
+// ! Paths : The directories the program can access. Every path is allowed if empty.
+// -------------------------
+// ! Env : Read and modify the environment variables.
+type Permissions struct {
+	Read  bool
+	Write bool
+	Paths []string
+	Env   bool
+}
+
+// ! ExitError : Returned by Run when the program calls "exit" with a non-zero code.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return "exit status " + strconv.Itoa(e.Code)
+}
+
+/**
+ * Create a new interpreter. The random number generator is seeded with the current time.
+ * The program can read and write files anywhere; restrict Permissions to sandbox it.
+ * @return *Interpreter - The new interpreter.
+ */
+func NewInterpreter() *Interpreter {
+	return &Interpreter{
+		Random:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		Methods:        DefaultMethods(),
+		Permissions:    Permissions{Read: true, Write: true, Env: true},
+		Input:          bufio.NewReader(os.Stdin),
+		Output:         os.Stdout,
+		MaxRecursion:   5000,
+		Clock:          NewSystemClock(),
+		MaxTraceFrames: 20,
+		TraceArguments: true,
+	}
+}
+
+/**
+ * Set the stream read by the console input functions (e.g. to feed scripted input).
+ * @param input : io.Reader - The input stream.
+ */
+func (interpreter *Interpreter) SetInput(input io.Reader) {
+	if reader, ok := input.(*bufio.Reader); ok {
+		(*interpreter).Input = reader
+	} else {
+		(*interpreter).Input = bufio.NewReader(input)
+	}
+}
+
+/**
+ * Seed the random number generator of the interpreter to get reproducible runs.
+ * @param seed : int64 - The seed.
+ */
+func (interpreter *Interpreter) Seed(seed int64) {
+	(*interpreter).Random = rand.New(rand.NewSource(seed))
+}
+
+/**
+ * Run Kode code with a new interpreter.
+ * @param code : string - The code to run.
+ * @return error - The error if one occurs (see Interpreter.Run). Use errors.As to get the *ErrorStack.
+ */
 func Run(code string) error {
+	return NewInterpreter().Run(code)
+}
+
+/**
+ * Run Kode code with the interpreter.
+ * @param code : string - The code to run.
+ * @return error - The error if one occurs: an *ExitError if the program exits with a non-zero code, *SyntaxErrors if
+ * the code is invalid (nothing is run), an *ErrorStack otherwise.
+ */
+func (interpreter *Interpreter) Run(code string) error {
+	_, err := interpreter.execute(code, false)
+	return err
+}
+
+/**
+ * Run Kode code with the interpreter and get the value of its last line if it is an expression (e.g. "1 + 2").
+ * @param code : string - The code to run.
+ * @return *Variable - The value of the last line, or nil if the last line is a statement.
+ * @return error - The error if one occurs (see Run).
+ */
+func (interpreter *Interpreter) Eval(code string) (*Variable, error) {
+	return interpreter.execute(code, true)
+}
+
+/**
+ * Run Kode code in a new main scope.
+ * @param code : string - The code to run.
+ * @param evaluateLast : bool - True to evaluate the last line if it is an expression (see Eval).
+ * @return *Variable - The value of the last line, or nil.
+ * @return error - The error if one occurs (see Run).
+ */
+func (interpreter *Interpreter) execute(code string, evaluateLast bool) (*Variable, error) {
 
 	// Return if the code is empty.
 	if code == "" {
-		return nil
+		return nil, nil
+	}
+
+	scope := interpreter.createMainScope("")
+	value, err := runMainScope(&scope, code, evaluateLast)
+
+	// Exiting with code 0 is a success
+	if exitError, ok := err.(*ExitError); ok && (*exitError).Code == 0 {
+		return nil, nil
+	}
+	return value, err
+}
+
+/**
+ * Run code in a main scope. The variables of the scope are kept after the run.
+ * @param scope : *Function - The main scope.
+ * @param code : string - The code to run.
+ * @param evaluateLast : bool - True to evaluate the last line if it is an expression (see Eval).
+ * @return *Variable - The value of the last line, or nil.
+ * @return error - The error if one occurs (see Run), or an *ExitError with code 0 if the program exits successfully.
+ */
+func runMainScope(scope *Function, code string, evaluateLast bool) (*Variable, error) {
+
+	// A shebang line (e.g. "#!/usr/bin/env kode") is a comment
+	if strings.HasPrefix(code, "#!") {
+		code = "#" + code[2:]
+	}
+
+	// The keywords chosen by a pragma are kept for the next runs of a session
+	interpreter := scope.GetInterpreter()
+	if pragma, _ := keywordsPragma(code); pragma != "" {
+		(*interpreter).keywords = pragma
 	}
 
-	// Create a new main scope.
-	_debug := CreateVariable(false)               // DEBUG variable prints debug info to console
-	_max_recursion := CreateVariable(int64(5000)) // Max recursion depth for functions
-	scope := CreateFunction("main", 1, []Argument{}, map[string]*Variable{"_DEBUG": &_debug, "_MAX_RECURSION": &_max_recursion}, "null", nil, strings.ReplaceAll(code, "\r", " "))
+	lines := LineParse(strings.ReplaceAll(code, "\r", " "))
+
+	// The functions declared by the previous runs of a session keep the lines after the new code to locate their errors
+	source := append([]string{}, lines...)
+	if previous := (*interpreter).source; len(previous) > len(source) {
+		source = append(source, previous[len(source):]...)
+	}
+	(*interpreter).source = source
+
+	// Report every syntax error before running anything
+	if errors := syntaxErrors(checkCode(code, (*interpreter).keywords)); len(errors) > 0 {
+		for _, err := range errors {
+			err.SetFile((*interpreter).File)
+			err.Localize((*interpreter).Language)
+		}
+		return nil, &SyntaxErrors{Errors: errors}
+	}
+
+	aliases := KEYWORD_ALIASES[(*interpreter).keywords]
+	for i := range lines {
+		lines[i] = translateKeywords(lines[i], aliases)
+	}
+
+	// Keep the trailing expression aside to get its value
+
+	expression, expressionOffset, expressionLine := "", 0, 0
+	for i := len(lines) - 1; evaluateLast && i >= 0; i-- {
+		if IsBlankLine(lines[i]) {
+			continue
+		}
+		// "print" already displays its value
+		if IsExpressionLine(lines[i]) && !isPrintCall(lines[i]) {
+			tokens := NewLineTokens(lines[i])
+			span := tokens.Rest()
+			expression, expressionOffset, expressionLine = tokens.Text(span), span.Start, i+1
+			lines[i] = ""
+		}
+		break
+	}
+
+	(*scope).Code = strings.Join(lines, "\n")
 
 	// Enter the main scope.
 	_, _, err := scope.Run([]*Variable{}, map[string]*Variable{}, 0, 0)
+	if err == nil && expression != "" {
+		var value Variable
+		value, err = EvaluateExpression(scope, expression, 0, expressionLine, expressionOffset)
+		if err == nil {
+			return &value, nil
+		}
+	}
+
+	if err == nil {
+		return nil, nil
+	}
 
-	// Convert the Kode stack error and return it.
+	// Point the errors at their code
+	err.Locate((*interpreter).source, aliases)
+	err.SetFile((*interpreter).File)
+	err.Localize((*interpreter).Language)
 
-	if err != nil {
-		return errors.New((*err).Error())
+	// The program exited on its own
+	if cause := err.Cause(); (*cause).Kind == EXIT {
+		return nil, &ExitError{Code: (*cause).ExitCode}
 	}
 
-	return nil
+	return nil, err
+}
 
+/**
+ * Check if a line of code is a call to "print".
+ * @param line : string - The line of code.
+ * @return bool - True if the line calls "print".
+ */
+func isPrintCall(line string) bool {
+	rest := strings.TrimSpace(line)
+	if !strings.HasPrefix(rest, "print") {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(rest, "print")), "(")
+}
+
+/**
+ * Create the main scope of a program with the system variables.
+ * @param code : string - The code of the program.
+ * @return Function - The main scope.
+ */
+func (interpreter *Interpreter) createMainScope(code string) Function {
+	_debug := CreateVariable((*interpreter).Debug)                // DEBUG variable prints debug info to console
+	_max_recursion := CreateVariable((*interpreter).MaxRecursion) // Max recursion depth for functions
+	_strict_index := CreateVariable(true)                         // STRICT_INDEX raises an error on out-of-bounds indexes instead of wrapping them
+
+	// Command-line arguments of the program
+	args := *createStringArray((*interpreter).Args)
+	args.Constant = true
+
+	(*interpreter).system = map[string]*Variable{"_DEBUG": &_debug, "_MAX_RECURSION": &_max_recursion, "_STRICT_INDEX": &_strict_index, "args": &args}
+	scope := CreateFunction("main", 0, []Argument{}, (*interpreter).system, "null", nil, code)
+	scope.Interpreter = interpreter
+	return scope
+}
+
+/**
+ * Get the interpreter running a scope. A new interpreter is created if the scope has none.
+ * @return *Interpreter - The interpreter.
+ */
+func (scope *Function) GetInterpreter() *Interpreter {
+	if (*scope).Interpreter == nil {
+		(*scope).Interpreter = NewInterpreter()
+	}
+	return (*scope).Interpreter
+}
+
+/**
+ * Get the system variables visible from a scope.
+ * @return map[string]*Variable - The system variables by name.
+ */
+func (scope *Function) SystemVariables() map[string]*Variable {
+	vars := map[string]*Variable{}
+	for _, name := range SYSTEM_VARIABLES {
+		if (*scope).VariableExists(name) {
+			vars[name] = (*scope).GetVariable(name)
+		}
+	}
+	return vars
+}
+
+/**
+ * Check if a variable of a scope is a system variable inherited from the main scope. The objects built from data
+ * (e.g. JSON objects or CSV records) have no system variables, so their fields can have any name.
+ * @param name : string - The name of the variable.
+ * @return bool - True if the variable is the system variable of the interpreter.
+ */
+func (function Function) isSystemVariable(name string) bool {
+	if function.Interpreter == nil || !IsSystemVariable(name) {
+		return false
+	}
+	system, exists := (*function.Interpreter).system[name]
+	return exists && system == function.Variables[name]
+}
+
+/**
+ * Check if a variable is defined by the interpreter.
+ * @param name : string - The name of the variable.
+ * @return bool - True if the variable is a system variable.
+ */
+func IsSystemVariable(name string) bool {
+	for _, systemName := range SYSTEM_VARIABLES {
+		if name == systemName {
+			return true
+		}
+	}
+	return false
 }
@@ -8,34 +8,35 @@ import (
  * Evaluate an expression.
  * @param scope : *Function - The scope of the expression.
  * @param str : string - The expression to evaluate.
+ * @param startLine : int - The line of the expression.
+ * @param offset : int - The byte offset of the expression in its line, so that the errors point at their code.
  * @return Variable - The result of the expression.
  * @return error - The error if any.
  */
-func EvaluateExpression(scope *Function, str string, depth int64, startLine int) (Variable, *ErrorStack) {
+func EvaluateExpression(scope *Function, str string, depth int64, startLine int, offset int) (Variable, *ErrorStack) {
 
 	// Tokenize the line
 	// "is" and "not" are implicitly parsed as well
 	// println("Evaluating expression: " + str)
-	tokens := InlineParse(str, []string{" ", "\t", "\r", "\n", ",", ".", "!=", "*", "/", "+", "-", "(", ")", "[", "]", "¬", "^", "%", "\"", "\\\"", "<=", ">=", ">", "<", "=="}, true)
+	tokens, spans := InlineParseSpans(str, offset, []string{" ", "\t", "\r", "\n", ",", ".", "!=", "*", "/", "+", "-", "(", ")", "[", "]", "¬", "^", "%", "\"", "\\\"", "<=", ">=", ">", "<", "=="}, true)
 
 	// Replace proper substractions with negation
 	// If there is a minus sign, and the next token is a number or a variable,
 	tokens = CheckForNegation(tokens)
 
 	// Add the tokens to the queue
-	queue := Queue{}
-	for _, token := range tokens {
-		queue.Push(token)
-	}
+	queue := NewTokens(str, offset, tokens, spans)
 
-	values := Stack{}    // Store values to evaluate
-	operators := Stack{} // Store operators to evaluate values
+	values := Stack{}        // Store values to evaluate
+	operators := Stack{}     // Store operators to evaluate values
+	operatorSpans := Stack{} // Store where each operator is in the line
 
 	// Loop through the all the tokens
 	for !queue.IsEmpty() {
 
 		// Get the next token
 		token, _ := queue.Pop()
+		span := queue.Last()
 		// println("Token: " + token.(string))
 
 		// ! SELF
@@ -57,44 +58,65 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 			value, hasValue := values.Pop()
 
 			if !hasValue {
-				return CreateVariable(nil), CreateError("Error: Improper use of '.'", startLine)
-			}
-
-			if value.(Variable).Type != "func" {
-				return CreateVariable(nil), CreateError("Error: Could not access a non-function ("+value.(Variable).Type+") using '.'", startLine)
+				return CreateVariable(nil), CreateTokenError(ErrorMessage(MSG_IMPROPER_DOT), span, startLine)
 			}
 
-			// Get the function
-			function := value.(Variable).Value.(Function)
-
-			// Get the next token being the function name
+			// Get the next token being the variable or method name
 			varName, hasVar := queue.Pop()
+			nameSpan := queue.Last()
 
 			if !hasVar {
-				return CreateVariable(nil), CreateError("Error: Improper use of '.'", startLine)
+				return CreateVariable(nil), CreateTokenError(ErrorMessage(MSG_IMPROPER_DOT), span, startLine)
 			}
 
-			// Get the function
-			variable := function.GetVariable(varName.(string))
+			// Get the variable if the value is a function
+			var variable *Variable
+			if value.(Variable).Type == "func" {
+				function := value.(Variable).Value.(Function)
+				variable = function.GetVariable(varName.(string))
+			}
 
-			// Check if the variable exists in the function
+			// ! METHOD
+			// Call a method of the value's type (e.g. "abc".upper())
 			if variable == nil {
-				return CreateVariable(nil), CreateError("Error: Variable '"+varName.(string)+"' does not exist in the function", startLine)
-			}
 
-			// Evaluate the sub variable
-			if (*variable).Type == "func" {
+				method, exists := (*scope).GetInterpreter().FindMethod(value.(Variable), varName.(string))
+				if !exists {
+					if value.(Variable).Type == "func" {
+						function := value.(Variable).Value.(Function)
+						return CreateVariable(nil), CreateTokenError(ErrorMessage(MSG_NOT_IN_FUNCTION, varName.(string)), nameSpan, startLine).WithHint(didYouMean(varName.(string), function.KnownNames()))
+					}
+					return CreateVariable(nil), CreateTokenError(ErrorMessage(MSG_UNKNOWN_METHOD, varName.(string), value.(Variable).Type), nameSpan, startLine).WithHint(didYouMean(varName.(string), (*scope).GetInterpreter().MethodNames(value.(Variable))))
+				}
+
+				// Extract the method's arguments
+				args, err := (*scope).ExtractFunctionArgs(queue, depth, startLine)
+				if err != nil {
+					return Variable{}, err
+				}
+
+				// The value is passed as the first argument
+				receiver := value.(Variable)
+				result, err := method(scope, append([]*Variable{&receiver}, args...), startLine)
+				if err != nil {
+					return Variable{}, err.At(nameSpan)
+				}
+
+				values.Push(*result)
+
+				// Evaluate the sub variable
+			} else if (*variable).Type == "func" {
 
 				// Check if the function is called
 				// Check if the next token is a parenthesis
 				nextToken, hasNextToken := queue.Peek()
 				if !hasNextToken || nextToken.(string) != "(" {
-					// Add the variable to the values stack
-					values.Push(*(*scope).GetVariable(token.(string)))
+					// Add the method to the values stack
+					values.Push(*variable)
 				} else {
 
 					// Extract the function's arguments
-					args, err := (*scope).ExtractFunctionArgs(&queue, depth, startLine)
+					args, err := (*scope).ExtractFunctionArgs(queue, depth, startLine)
 
 					if err != nil {
 						return Variable{}, err
@@ -105,9 +127,9 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 					copyFunc := CopyFunction(&function)
 					newVars := CopyVariableMap((*copyFunc).Parent.Variables)
 					(*copyFunc).Variables = newVars
-					instance, _, err := (*copyFunc).Run(args, map[string]*Variable{}, depth+1, 0)
+					instance, err := (*scope).CallFunction(copyFunc, varName.(string), args, depth+1, startLine, nameSpan.To(queue.Last()))
 					if err != nil {
-						return Variable{}, err.AddError(CreateError("Error: Unexpected error in function '"+token.(string)+"'", startLine))
+						return Variable{}, err
 					}
 
 					values.Push(*instance)
@@ -128,7 +150,8 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 			nextToken, hasNextToken := queue.Peek()
 
-			for hasNextToken && (nextToken.(string) == "." || IsNumber(nextToken.(string))) {
+			// A "." only belongs to the number if it is followed by digits (e.g. "5.toString()" is a method call)
+			for hasNextToken && ((nextToken.(string) == "." && len(queue.Queue) > 1 && IsNumber(queue.Queue[1].(string))) || IsNumber(nextToken.(string))) {
 
 				queue.Pop()
 
@@ -137,7 +160,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 					// If it meets another ".", it is not a float and has an invalid format (error)
 					if isFloat {
-						return CreateVariable(nil), CreateError("Error: Invalid number format", startLine)
+						return CreateVariable(nil), CreateTokenError(ErrorMessage(MSG_NUMBER_FORMAT), span.To(queue.Last()), startLine)
 					} else {
 						isFloat = true
 					}
@@ -195,7 +218,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 			// Check for incomplete string errors
 			if nextToken == nil || nextToken.(string) != "\"" {
-				return Variable{}, CreateError("Error: Missing closing quote for string", startLine)
+				return Variable{}, CreateTokenError(ErrorMessage(MSG_MISSING_QUOTE), span, startLine)
 			} else {
 
 				// No errors found, push the string to the values stack
@@ -203,6 +226,10 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 			}
 
 			// Check if the token is a variable
+			// ! BUILT-IN CONSTANT
+		} else if constant, isConstant := BuiltInConstant(token.(string)); isConstant {
+			values.Push(constant)
+
 			// ! NEW VARIABLE
 		} else if token.(string) == "new" {
 
@@ -210,16 +237,17 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 			nextToken, valid := queue.Pop()
 
 			if !valid {
-				return Variable{}, CreateError("Error: Missing variable name after 'new'", startLine)
+				return Variable{}, CreateTokenError(ErrorMessage(MSG_MISSING_NEW_NAME), span, startLine)
 			}
+			nameSpan := queue.Last()
 
 			if !(*scope).VariableExists(nextToken.(string)) {
-				return Variable{}, CreateError("Error: Variable '"+nextToken.(string)+"' does not exist", startLine)
+				return Variable{}, CreateTokenError(ErrorMessage(MSG_UNDEFINED, nextToken.(string)), nameSpan, startLine).WithHint(didYouMean(nextToken.(string), (*scope).KnownNames()))
 			}
 
 			// Check if the variable is a function
 			if (*scope).GetVariable(nextToken.(string)).Type != "func" {
-				return Variable{}, CreateError("Error: Variable '"+nextToken.(string)+"' is not a function", startLine)
+				return Variable{}, CreateTokenError(ErrorMessage(MSG_NOT_A_FUNCTION, nextToken.(string)), nameSpan, startLine)
 			}
 
 			// Check if the function is called
@@ -231,7 +259,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 			} else {
 
 				// Extract the function's arguments
-				args, err := (*scope).ExtractFunctionArgs(&queue, depth, startLine)
+				args, err := (*scope).ExtractFunctionArgs(queue, depth, startLine)
 
 				if err != nil {
 					return Variable{}, err
@@ -241,11 +269,11 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 				function := (*scope).GetVariable(nextToken.(string)).Value.(Function)
 				copyFunc := CopyFunction(&function)
 
-				(*copyFunc).Variables = map[string]*Variable{"_DEBUG": (*scope).GetVariable("_DEBUG"), "_MAX_RECURSION": (*scope).GetVariable("_MAX_RECURSION")}
+				(*copyFunc).Variables = (*scope).SystemVariables()
 				(*copyFunc).Parent = copyFunc
-				instance, _, err := (*copyFunc).Run(args, map[string]*Variable{}, depth+1, 0)
+				instance, err := (*scope).CallFunction(copyFunc, nextToken.(string), args, depth+1, startLine, span.To(queue.Last()))
 				if err != nil {
-					return Variable{}, CreateError("Error: Unexpected error when calling function '"+nextToken.(string)+"'", startLine)
+					return Variable{}, err
 				}
 
 				values.Push(*instance)
@@ -265,7 +293,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 					values.Push(*(*scope).GetVariable(token.(string)))
 				} else {
 					// Extract the function's arguments
-					args, err := (*scope).ExtractFunctionArgs(&queue, depth, startLine)
+					args, err := (*scope).ExtractFunctionArgs(queue, depth, startLine)
 
 					if err != nil {
 						return Variable{}, err
@@ -276,9 +304,9 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 					copyFunc := CopyFunction(&function)
 					newVars := CopyVariableMap((*copyFunc).Parent.Variables)
 					(*copyFunc).Variables = newVars
-					instance, _, err := (*copyFunc).Run(args, map[string]*Variable{}, depth+1, 0)
+					instance, err := (*scope).CallFunction(copyFunc, token.(string), args, depth+1, startLine, span.To(queue.Last()))
 					if err != nil {
-						return Variable{}, err.AddError(CreateError("Error: Unexpected error when calling function '"+token.(string)+"'", startLine))
+						return Variable{}, err
 					}
 
 					values.Push(*instance)
@@ -295,34 +323,34 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 					// Check if array
 					if !isArrayType(variable.Type) && variable.Type != "string" {
-						return Variable{}, CreateError("Error: '"+token.(string)+"' cannot access that index because it might not be an array or a string", startLine)
+						return Variable{}, CreateTokenError(ErrorMessage(MSG_NOT_INDEXABLE, token.(string)), span, startLine)
 					}
 
 					queue.Pop()
-					indexArray, err := (*scope).ExtractArrayValues(&queue, depth, startLine)
+					indexArray, err := (*scope).ExtractArrayValues(queue, depth, startLine)
 					if err != nil {
 						return Variable{}, err
 					}
 					if len(indexArray) != 1 || indexArray[0].Type != "int" {
-						return Variable{}, CreateError("Error: Array index must be an integer", startLine)
+						return Variable{}, CreateTokenError(ErrorMessage(MSG_INDEX_INT), span.To(queue.Last()), startLine)
 					}
 
 					// Extract the max index
 					size, err := GetArraySize(&variable, startLine)
 					if err != nil {
-						return Variable{}, err
+						return Variable{}, err.At(span.To(queue.Last()))
 					}
 
-					index := indexArray[0].Value.(int64) % size
-					if index < 0 { // Handle negative indexes
-						index += size
+					index, err := (*scope).ResolveIndex(indexArray[0].Value.(int64), size, startLine)
+					if err != nil {
+						return Variable{}, err.At(span.To(queue.Last()))
 					}
 
 					if variable.Type == "string" {
-						variable = CreateVariable(string(variable.Value.(string)[index]))
+						variable = CreateVariable(string([]rune(variable.Value.(string))[index]))
 						peeked, validPeek = queue.Peek()
 					} else {
-						variable = variable.Value.([]Variable)[index]
+						variable = variable.Elements()[index]
 						peeked, validPeek = queue.Peek()
 					}
 
@@ -339,7 +367,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 		} else if token.(string) == "[" {
 
 			// Extract the array's value
-			array, err := (*scope).ExtractArrayValues(&queue, depth, startLine)
+			array, err := (*scope).ExtractArrayValues(queue, depth, startLine)
 			if err != nil {
 				return Variable{}, err
 			}
@@ -352,6 +380,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 			// ! LEFT PARENTHESIS
 		} else if token.(string) == "(" {
 			operators.Push(token.(string)) // Push the token to the operators stack
+			operatorSpans.Push(span)
 
 			// Check if the token is a right parenthesis
 			// ! RIGHT PARENTHESIS
@@ -360,16 +389,17 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 			// Check if the operators stack is empty
 			if !valid {
-				return Variable{}, CreateError("Error: Invalid expression. Missing a \"(\"", startLine)
+				return Variable{}, CreateTokenError(ErrorMessage(MSG_MISSING_OPENING, ")"), span, startLine)
 			}
 
 			for peeked.(string) != "(" {
 
 				// Pop the operator from the stack
 				operator, valid := operators.Pop()
+				operatorSpan, _ := operatorSpans.Pop()
 
 				if !valid {
-					return Variable{}, CreateError("Error: Invalid expression. Missing a \"(\"", startLine)
+					return Variable{}, CreateTokenError(ErrorMessage(MSG_MISSING_OPENING, ")"), span, startLine)
 				}
 
 				// Check for negation
@@ -377,7 +407,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 					val2, exists2 := values.Pop()
 
 					if !exists2 {
-						return Variable{}, CreateError("Error: Invalid expression (1). Missing a value", startLine)
+						return Variable{}, CreateTokenError(ErrorMessage(MSG_MISSING_AFTER, operator.(string)), operatorSpan.(Span), startLine)
 					}
 
 					// Compute the result
@@ -385,7 +415,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 					// Handle any operation errors
 					if opError != nil {
-						return Variable{}, opError
+						return Variable{}, opError.At(operatorSpan.(Span))
 					}
 
 					values.Push(result) // Push the result to the values stack
@@ -395,7 +425,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 					val2, exists2 := values.Pop()
 					val1, exists1 := values.Pop()
 					if !exists1 || !exists2 {
-						return Variable{}, CreateError("Error: Invalid expression (2). Missing a value", startLine)
+						return Variable{}, CreateTokenError(ErrorMessage(MSG_MISSING_SIDE, operator.(string)), operatorSpan.(Span), startLine)
 					}
 
 					// Compute the result
@@ -403,7 +433,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 					// Handle any operation errors
 					if opError != nil {
-						return Variable{}, opError
+						return Variable{}, opError.At(operatorSpan.(Span))
 					}
 
 					values.Push(result) // Push the result to the values stack
@@ -412,12 +442,13 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 				peeked, valid = operators.Peek()
 
 				if !valid {
-					return Variable{}, CreateError("Error: Invalid expression (3). Missing a value", startLine)
+					return Variable{}, CreateTokenError(ErrorMessage(MSG_MISSING_OPENING, ")"), span, startLine)
 				}
 			}
 
 			// Pop the left parenthesis from the stack
 			operators.Pop()
+			operatorSpans.Pop()
 
 			// ! OPERATOR
 		} else if isOperator(token.(string)) {
@@ -431,12 +462,13 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 				// Pop the operator from the stack
 				operator, _ := operators.Pop()
+				operatorSpan, _ := operatorSpans.Pop()
 
 				// Check for negation
 				if operator.(string) == "¬" || operator.(string) == "not" {
 					val2, exists2 := values.Pop()
 					if !exists2 {
-						return Variable{}, CreateError("Error: Invalid expression (4). Missing a value", startLine)
+						return Variable{}, CreateTokenError(ErrorMessage(MSG_MISSING_AFTER, operator.(string)), operatorSpan.(Span), startLine)
 					}
 
 					// Compute the result
@@ -444,7 +476,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 					// Handle any operation errors
 					if opError != nil {
-						return Variable{}, opError
+						return Variable{}, opError.At(operatorSpan.(Span))
 					}
 
 					values.Push(result) // Push the result to the values stack
@@ -454,7 +486,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 					val2, exists2 := values.Pop()
 					val1, exists1 := values.Pop()
 					if !exists1 || !exists2 {
-						return Variable{}, CreateError("Error: Invalid expression (5). Missing a value", startLine)
+						return Variable{}, CreateTokenError(ErrorMessage(MSG_MISSING_SIDE, operator.(string)), operatorSpan.(Span), startLine)
 					}
 
 					// Compute the result
@@ -462,7 +494,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 					// Handle any operation errors
 					if opError != nil {
-						return Variable{}, opError
+						return Variable{}, opError.At(operatorSpan.(Span))
 					}
 
 					values.Push(result) // Push the result to the values stack
@@ -473,12 +505,13 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 			}
 
 			operators.Push(currOp) // Push the operator to the operators stack
+			operatorSpans.Push(span)
 
 			// ! PREBUILT FUNCTION
 		} else if ExistsBuiltIn(token.(string)) {
 
 			// Extract the function's arguments
-			args, err := (*scope).ExtractFunctionArgs(&queue, depth, startLine)
+			args, err := (*scope).ExtractFunctionArgs(queue, depth, startLine)
 
 			if err != nil {
 				return Variable{}, err
@@ -486,28 +519,31 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 			// Call the function
 
-			result, err := RunBuiltIn(token.(string), args, startLine)
+			result, err := RunBuiltIn(scope, token.(string), args, startLine)
 			if err != nil {
-				return Variable{}, err
+				return Variable{}, err.At(span)
 			}
 
 			values.Push(*result)
 
 			// ! UNKNOWN
+		} else if varFormat.MatchString(token.(string)) && !IsReservedWord(token.(string)) {
+			return Variable{}, CreateTokenError(ErrorMessage(MSG_UNKNOWN_NAME, token.(string)), span, startLine).WithHint(didYouMean(token.(string), (*scope).KnownNames()))
 		} else {
-			return Variable{}, CreateError("Error: Invalid expression \""+token.(string)+"\"", startLine)
+			return Variable{}, CreateTokenError(ErrorMessage(MSG_INVALID_EXPRESSION, token.(string)), span, startLine)
 		}
 
 	}
 
 	for !operators.IsEmpty() {
 		operator, _ := operators.Pop()
+		operatorSpan, _ := operatorSpans.Pop()
 
 		// Check for negation
 		if operator.(string) == "¬" || operator.(string) == "not" {
 			val2, exists2 := values.Pop()
 			if !exists2 {
-				return Variable{}, CreateError("Error: Invalid expression (7). Missing a value", startLine)
+				return Variable{}, CreateTokenError(ErrorMessage(MSG_MISSING_AFTER, operator.(string)), operatorSpan.(Span), startLine)
 			}
 
 			// Compute the result
@@ -515,7 +551,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 			// Handle any operation errors
 			if opError != nil {
-				return Variable{}, opError
+				return Variable{}, opError.At(operatorSpan.(Span))
 			}
 
 			values.Push(result) // Push the result to the values stack
@@ -525,7 +561,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 			val2, exists2 := values.Pop()
 			val1, exists1 := values.Pop()
 			if !exists1 || !exists2 {
-				return Variable{}, CreateError("Error: Invalid expression (8). Missing a value", startLine)
+				return Variable{}, CreateTokenError(ErrorMessage(MSG_MISSING_SIDE, operator.(string)), operatorSpan.(Span), startLine)
 			}
 
 			// Compute the result
@@ -533,7 +569,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 			// Handle any operation errors
 			if opError != nil {
-				return Variable{}, opError
+				return Variable{}, opError.At(operatorSpan.(Span))
 			}
 
 			values.Push(result) // Push the result to the values stack
@@ -545,7 +581,7 @@ func EvaluateExpression(scope *Function, str string, depth int64, startLine int)
 
 	// Check if the values stack is empty. If it is, then the expression is invalid
 	if !exists {
-		return Variable{}, CreateError("Error: Empty expression", startLine)
+		return Variable{}, CreateError(ErrorMessage(MSG_EMPTY_EXPRESSION), startLine)
 	}
 
 	return value.(Variable), nil
@@ -1,14 +1,20 @@
 package kode
 
+import "regexp"
+
+// Condition of a for-each loop (e.g. "row in rows")
+var forEachFormat, _ = regexp.Compile(`^\s*([\p{L}_][\p{L}\p{N}_]*)\s+in\s+(.+)$`)
+
 type LoopBlock struct {
-	Condition string
-	Code      string
-	LoopIndex int
+	Condition       string
+	ConditionOffset int
+	Code            string
+	LoopIndex       int
 }
 
-func ParseLoopBlock(tokens *Queue, currentLine int, lines []string, startLine int) (LoopBlock, int, *ErrorStack) {
+func ParseLoopBlock(tokens *Tokens, currentLine int, lines []string, startLine int) (LoopBlock, int, *ErrorStack) {
 
-	condition := InlineQueueToString(tokens)
+	condition := tokens.Rest()
 
 	currentLine++          // Skip to next line to avoid including the condition in the code
 	foundBoundary := false // Flag to indicate if the boundary of the condition has been found
@@ -42,9 +48,116 @@ func ParseLoopBlock(tokens *Queue, currentLine int, lines []string, startLine in
 	}
 
 	if !foundBoundary {
-		return LoopBlock{}, currentLine, CreateError("Unable to find the end of the loop block", startIndex)
+		return LoopBlock{}, currentLine, unclosedBlockError("for", "for", lines, startIndex-1, startLine)
+	}
+
+	return LoopBlock{tokens.Text(condition), condition.Start, code, startIndex}, currentLine, nil
+
+}
+
+/**
+ * Split the condition of a for-each loop (e.g. "for item in items") into the name of the loop variable and the iterated expression.
+ * @param condition : string - The condition of the loop.
+ * @return string - The name of the loop variable.
+ * @return string - The iterated expression.
+ * @return bool - True if the condition is a for-each condition.
+ */
+func ParseForEach(condition string) (string, string, bool) {
+	match := forEachFormat.FindStringSubmatch(condition)
+	if match == nil {
+		return "", "", false
 	}
+	return match[1], match[2], true
+}
 
-	return LoopBlock{condition, code, startIndex}, currentLine, nil
+/**
+ * Run a for-each loop. Arrays are iterated element by element (changes to the array during the loop are not seen),
+ * strings character by character and CSV readers row by row.
+ * @param name : string - The name of the loop variable.
+ * @param iterable : string - The iterated expression.
+ * @param loopBlock : LoopBlock - The loop.
+ * @param depth : int64 - The recursion depth.
+ * @param line : int - The line of the loop in the program.
+ * @return *Variable - The returned value if the loop returns.
+ * @return int - 1 if the loop returns, 0 otherwise.
+ * @return error - The error if one occurs.
+ */
+func (scope *Function) RunForEach(name string, iterable string, loopBlock LoopBlock, depth int64, line int) (*Variable, int, *ErrorStack) {
+	if !HasValidVariableName(name) {
+		return NullVariable(), 0, CreateError(ErrorMessage(MSG_VARIABLE_NAME, name), line).At(Span{loopBlock.ConditionOffset, loopBlock.ConditionOffset + len(name)})
+	}
+
+	// The iterated expression ends the condition
+	offset := loopBlock.ConditionOffset + len(loopBlock.Condition) - len(iterable)
+	evaluatedIterable, err := EvaluateExpression(scope, iterable, depth, line, offset)
+	if err != nil {
+		return NullVariable(), 0, err.AddError(scope.ContextError())
+	}
+
+	// Get the next value of the loop variable, or nil at the end
+	var next func() (*Variable, *ErrorStack)
+	switch value := evaluatedIterable.Value.(type) {
+	case *Array:
+		elements := value.Elements
+		next = func() (*Variable, *ErrorStack) {
+			if len(elements) == 0 {
+				return nil, nil
+			}
+			element := elements[0]
+			elements = elements[1:]
+			return &element, nil
+		}
+	case string:
+		chars := []rune(value)
+		next = func() (*Variable, *ErrorStack) {
+			if len(chars) == 0 {
+				return nil, nil
+			}
+			char := CreateVariable(string(chars[0]))
+			chars = chars[1:]
+			return &char, nil
+		}
+	case *CSVReader:
+		// The file is closed when the loop stops, even early (e.g. "break", "return" or an error)
+		defer value.Close()
+		next = func() (*Variable, *ErrorStack) {
+			row, err := value.Next()
+			if err != nil {
+				return nil, csvError("for", value.Source, err, line)
+			}
+			return row, nil
+		}
+	default:
+		return NullVariable(), 0, CreateError(ErrorMessage(MSG_CANNOT_ITERATE, evaluatedIterable.Type), line).At(Span{offset, offset + len(iterable)})
+	}
+
+	for {
+		element, err := next()
+		if err != nil {
+			return NullVariable(), 0, err
+		}
+		if element == nil {
+			break
+		}
+		(*element).Constant = false
+		(*element).Frozen = false
+
+		forLoop := CreateFunction("for", line, []Argument{}, (*scope).Variables, "val", scope, loopBlock.Code)
+		returnValue, toReturn, err := forLoop.Run([]*Variable{}, map[string]*Variable{name: element}, depth, line)
+		if err != nil {
+			return NullVariable(), 0, err
+		}
+
+		// Return the value to the caller
+		if toReturn == 1 {
+			return returnValue, toReturn, nil
+		}
+
+		// Exit the for loop if the break statement was called
+		if toReturn == 2 {
+			break
+		}
+	}
 
+	return NullVariable(), 0, nil
 }
@@ -0,0 +1,445 @@
+package kode
+
+import (
+	"math"
+)
+
+/**
+ * Get the value of a built-in math constant.
+ * @param name : string - The name of the constant.
+ * @return Variable - The value of the constant.
+ * @return bool - True if the constant exists.
+ */
+func BuiltInConstant(name string) (Variable, bool) {
+	switch name {
+	case "PI":
+		return CreateVariable(math.Pi), true
+	case "E":
+		return CreateVariable(math.E), true
+	case "INF":
+		return CreateVariable(math.Inf(1)), true
+	case "NAN":
+		return CreateVariable(math.NaN()), true
+	default:
+		return Variable{}, false
+	}
+}
+
+/**
+ * Get the value of a number as a float.
+ * @param variable : *Variable - The int or float variable.
+ * @return float64 - The value as a float.
+ * @return bool - True if the variable is a number.
+ */
+func toNumber(variable *Variable) (float64, bool) {
+	switch (*variable).Type {
+	case "int":
+		return float64((*variable).Value.(int64)), true
+	case "float":
+		return (*variable).Value.(float64), true
+	default:
+		return 0, false
+	}
+}
+
+/**
+ * Apply a float function to a single int or float argument.
+ * @param name : string - The name of the Kode function.
+ * @param args :[]*Variable - The arguments to the function.
+ * @param function : func(float64) float64 - The function to apply.
+ * @return *Variable - The float result.
+ * @return error - The error if one occurs.
+**/
+func applyFloatFunction(name string, args []*Variable, startLine int, function func(float64) float64) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", name), startLine)
+	}
+
+	x, ok := toNumber(args[0])
+	if !ok {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a float or an int", name), startLine)
+	}
+
+	variable := CreateVariable(function(x))
+	return &variable, nil
+}
+
+/**
+ * Apply a float function to two int or float arguments.
+ * @param name : string - The name of the Kode function.
+ * @param args :[]*Variable - The arguments to the function.
+ * @param function : func(float64, float64) float64 - The function to apply.
+ * @return *Variable - The float result.
+ * @return error - The error if one occurs.
+**/
+func applyFloatFunction2(name string, args []*Variable, startLine int, function func(float64, float64) float64) (*Variable, *ErrorStack) {
+	if len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", name), startLine)
+	}
+
+	x, okX := toNumber(args[0])
+	y, okY := toNumber(args[1])
+	if !okX || !okY {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENTS_MUST, "floats or ints", name), startLine)
+	}
+
+	variable := CreateVariable(function(x, y))
+	return &variable, nil
+}
+
+/**
+ * Get the sine of an angle in radians.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Sin(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return applyFloatFunction("sin", args, startLine, math.Sin)
+}
+
+/**
+ * Get the cosine of an angle in radians.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Cos(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return applyFloatFunction("cos", args, startLine, math.Cos)
+}
+
+/**
+ * Get the tangent of an angle in radians.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Tan(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return applyFloatFunction("tan", args, startLine, math.Tan)
+}
+
+/**
+ * Get the angle in radians of the point (x, y), given as y then x.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Atan2(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return applyFloatFunction2("atan2", args, startLine, math.Atan2)
+}
+
+/**
+ * Get e raised to the power of a number.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Exp(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return applyFloatFunction("exp", args, startLine, math.Exp)
+}
+
+/**
+ * Get the natural logarithm of a number.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Log(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return applyFloatFunction("log", args, startLine, math.Log)
+}
+
+/**
+ * Get the base 10 logarithm of a number.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Log10(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return applyFloatFunction("log10", args, startLine, math.Log10)
+}
+
+/**
+ * Get the length of the hypotenuse of a right triangle, i.e. sqrt(x*x + y*y).
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Hypot(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return applyFloatFunction2("hypot", args, startLine, math.Hypot)
+}
+
+/**
+ * Round down a number. Ints are returned as is.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Floor(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) == 1 && args[0].Type == "int" {
+		variable := CreateVariable(args[0].Value.(int64))
+		return &variable, nil
+	}
+	return applyFloatFunction("floor", args, startLine, math.Floor)
+}
+
+/**
+ * Round up a number. Ints are returned as is.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Ceil(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) == 1 && args[0].Type == "int" {
+		variable := CreateVariable(args[0].Value.(int64))
+		return &variable, nil
+	}
+	return applyFloatFunction("ceil", args, startLine, math.Ceil)
+}
+
+/**
+ * Get the absolute value of a number. The type of the number is kept.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs (e.g. the smallest int has no positive int).
+**/
+func Abs(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) == 1 && args[0].Type == "int" {
+		i := args[0].Value.(int64)
+		if i == math.MinInt64 {
+			return NullVariable(), CreateError(ErrorMessage(MSG_INT_OVERFLOW, "abs"), startLine)
+		}
+		if i < 0 {
+			i = -i
+		}
+		variable := CreateVariable(i)
+		return &variable, nil
+	}
+	return applyFloatFunction("abs", args, startLine, math.Abs)
+}
+
+/**
+ * Get the numbers compared by "min" and "max", either as arguments or inside a single array.
+ * @param name : string - The name of the Kode function.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return []*Variable - The numbers to compare.
+ * @return error - The error if one occurs.
+**/
+func extractNumbers(name string, args []*Variable, startLine int) ([]*Variable, *ErrorStack) {
+	numbers := args
+	if len(args) == 1 && isArrayType(args[0].Type) {
+		numbers = []*Variable{}
+		for i := range args[0].Elements() {
+			numbers = append(numbers, &args[0].Elements()[i])
+		}
+	}
+
+	if len(numbers) == 0 {
+		return nil, CreateError(ErrorMessage(MSG_AT_LEAST_NUMBER, name), startLine)
+	}
+
+	for _, number := range numbers {
+		if _, ok := toNumber(number); !ok {
+			return nil, CreateError(ErrorMessage(MSG_ARGUMENTS_MUST, "floats or ints", name), startLine)
+		}
+	}
+
+	return numbers, nil
+}
+
+/**
+ * Compare numbers and keep one of them. The result is an int if every number is an int, otherwise a float.
+ * @param name : string - The name of the Kode function.
+ * @param args :[]*Variable - The arguments to the function.
+ * @param keepFirst : func(float64, float64) bool - True if the first number should be kept over the second.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func selectNumber(name string, args []*Variable, startLine int, keepFirst func(float64, float64) bool) (*Variable, *ErrorStack) {
+	numbers, err := extractNumbers(name, args, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	isFloat := false
+	selected := numbers[0]
+	for _, number := range numbers {
+		isFloat = isFloat || number.Type == "float"
+		x, _ := toNumber(number)
+		y, _ := toNumber(selected)
+		if !keepFirst(y, x) {
+			selected = number
+		}
+	}
+
+	if isFloat {
+		f, _ := toNumber(selected)
+		variable := CreateVariable(f)
+		return &variable, nil
+	}
+
+	variable := CreateVariable(selected.Value.(int64))
+	return &variable, nil
+}
+
+/**
+ * Get the smallest number, either from the arguments or from a single array.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Min(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return selectNumber("min", args, startLine, func(a float64, b float64) bool { return a <= b })
+}
+
+/**
+ * Get the greatest number, either from the arguments or from a single array.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Max(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return selectNumber("max", args, startLine, func(a float64, b float64) bool { return a >= b })
+}
+
+/**
+ * Restrict a number between a lower and an upper bound.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Clamp(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 3 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "3", "clamp"), startLine)
+	}
+
+	_, okX := toNumber(args[0])
+	low, okLow := toNumber(args[1])
+	high, okHigh := toNumber(args[2])
+	if !okX || !okLow || !okHigh {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENTS_MUST, "floats or ints", "clamp"), startLine)
+	}
+	if low > high {
+		return NullVariable(), CreateError(ErrorMessage(MSG_BOUNDS, "clamp"), startLine)
+	}
+
+	lower, err := Max(args[:2], startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+	return Min([]*Variable{lower, args[2]}, startLine)
+}
+
+/**
+ * Get the greatest common divisor of two ints.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs (e.g. the divisor of the smallest int and 0 is too large for an int).
+**/
+func Gcd(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", "gcd"), startLine)
+	}
+
+	if args[0].Type != "int" || args[1].Type != "int" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENTS_MUST, "ints", "gcd"), startLine)
+	}
+
+	divisor := gcd(args[0].Value.(int64), args[1].Value.(int64))
+	if divisor < 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_INT_OVERFLOW, "gcd"), startLine)
+	}
+	variable := CreateVariable(divisor)
+	return &variable, nil
+}
+
+/**
+ * Get the least common multiple of two ints.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs (e.g. a multiple too large for an int).
+**/
+func Lcm(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", "lcm"), startLine)
+	}
+
+	if args[0].Type != "int" || args[1].Type != "int" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENTS_MUST, "ints", "lcm"), startLine)
+	}
+
+	a, b := args[0].Value.(int64), args[1].Value.(int64)
+	if a == 0 || b == 0 {
+		variable := CreateVariable(int64(0))
+		return &variable, nil
+	}
+
+	divisor := gcd(a, b)
+	if divisor < 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_INT_OVERFLOW, "lcm"), startLine)
+	}
+
+	// The multiple, or its opposite, cannot be larger than the largest int
+	quotient := a / divisor
+	lcm := quotient * b
+	if lcm/b != quotient || lcm == math.MinInt64 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_INT_OVERFLOW, "lcm"), startLine)
+	}
+	if lcm < 0 {
+		lcm = -lcm
+	}
+	variable := CreateVariable(lcm)
+	return &variable, nil
+}
+
+/**
+ * Compute the greatest common divisor with the Euclidean algorithm.
+ * @return int64 - The positive greatest common divisor, or a negative number if it is too large for an int64.
+ */
+func gcd(a int64, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+/**
+ * Check if a number is not a number (NaN).
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func IsNaN(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "isNaN"), startLine)
+	}
+
+	x, ok := toNumber(args[0])
+	if !ok {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a float or an int", "isNaN"), startLine)
+	}
+
+	variable := CreateVariable(math.IsNaN(x))
+	return &variable, nil
+}
+
+/**
+ * Check if a number is infinite (positive or negative).
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func IsInf(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "isInf"), startLine)
+	}
+
+	x, ok := toNumber(args[0])
+	if !ok {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a float or an int", "isInf"), startLine)
+	}
+
+	variable := CreateVariable(math.IsInf(x, 0))
+	return &variable, nil
+}
@@ -0,0 +1,23 @@
+package kode
+
+import "testing"
+
+func TestIntOverflow(t *testing.T) {
+	tests := []string{
+		`abs(-9223372036854775807 - 1)`,
+		`lcm(9223372036854775807, 2)`,
+		`lcm(-9223372036854775807 - 1, 1)`,
+		`gcd(-9223372036854775807 - 1, 0)`,
+	}
+
+	for _, code := range tests {
+		if errorCode := runError(t, code).ErrorCode(); errorCode != E_INVALID_ARGUMENT {
+			t.Errorf("%q: expected %s, got %s", code, E_INVALID_ARGUMENT, errorCode)
+		}
+	}
+
+	expected := "9223372036854775807 9223372036854775807 12\n"
+	if output := runOutput(t, `print(abs(-9223372036854775807), lcm(-9223372036854775807, 1), lcm(-4, 6))`); output != expected {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+}
@@ -0,0 +1,535 @@
+package kode
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Languages of the messages. The messages are created in English and translated when they are shown (see Localize).
+var LANGUAGES = []string{"en", "fr"}
+
+// Identifiers of the messages of the catalogue
+const (
+	// Parts of the rendered errors
+	MSG_ERROR            = "error"
+	MSG_IN_FUNCTION      = "in_function"
+	MSG_ON_LINE          = "on_line"
+	MSG_COLUMN           = "column"
+	MSG_AT               = "at"
+	MSG_CALL_STACK       = "call_stack"
+	MSG_ELIDED_FRAME     = "elided_frame"
+	MSG_ELIDED_FRAMES    = "elided_frames"
+	MSG_ELIDED_FRAME_OF  = "elided_frame_of"
+	MSG_ELIDED_FRAMES_OF = "elided_frames_of"
+	MSG_DID_YOU_MEAN     = "did_you_mean"
+	MSG_DID_YOU_MEAN_END = "did_you_mean_end"
+
+	// Messages of the errors
+	MSG_READ_FILE            = "read_file"
+	MSG_READ_STANDARD_IN     = "read_standard_input"
+	MSG_UNKNOWN_NAME         = "unknown_name"
+	MSG_UNKNOWN_VARIABLE     = "unknown_variable"
+	MSG_NOT_IN_FUNCTION      = "not_in_function"
+	MSG_UNDEFINED            = "undefined"
+	MSG_UNKNOWN_FIELD        = "unknown_field"
+	MSG_UNKNOWN_METHOD       = "unknown_method"
+	MSG_UNKNOWN_COMMAND      = "unknown_command"
+	MSG_UNKNOWN_KEYWORDS     = "unknown_keywords"
+	MSG_UNCLOSED_BLOCK       = "unclosed_block"
+	MSG_UNEXPECTED           = "unexpected"
+	MSG_UNEXPECTED_AFTER     = "unexpected_after"
+	MSG_UNEXPECTED_END       = "unexpected_end"
+	MSG_UNEXPECTED_BLOCK_END = "unexpected_block_end"
+	MSG_UNEXPECTED_ELSE      = "unexpected_else"
+	MSG_UNEXPECTED_CATCH     = "unexpected_catch"
+	MSG_MISMATCHED_END       = "mismatched_end"
+	MSG_MISSING_END_NAME     = "missing_end_name"
+	MSG_BREAK_OUTSIDE        = "break_outside"
+	MSG_MISSING_CONDITION    = "missing_condition"
+	MSG_MISSING_AFTER        = "missing_after"
+	MSG_MISSING_SIDE         = "missing_side"
+	MSG_MISSING_OPENING      = "missing_opening"
+	MSG_MISSING_CLOSING      = "missing_closing"
+	MSG_MISSING_BRACKET      = "missing_bracket"
+	MSG_MISSING_QUOTE        = "missing_quote"
+	MSG_UNCLOSED_STRING      = "unclosed_string"
+	MSG_DIVIDE_BY_ZERO       = "divide_by_zero"
+	MSG_MODULO_BY_ZERO       = "modulo_by_zero"
+	MSG_INVALID_OPERATION    = "invalid_operation"
+	MSG_INVALID_NEGATION     = "invalid_negation"
+	MSG_CANNOT_COMPARE       = "cannot_compare"
+	MSG_CONDITION_BOOL       = "condition_bool"
+	MSG_CONDITION_TYPE       = "condition_type"
+	MSG_RECURSION_LIMIT      = "recursion_limit"
+	MSG_INDEX_OUT            = "index_out_of_bounds"
+	MSG_CONSTANT             = "constant"
+	MSG_VARIABLE_EXISTS      = "variable_exists"
+	MSG_VARIABLE_NAME        = "variable_name"
+	MSG_FUNCTION_NAME        = "function_name"
+	MSG_PARAMETER_NAME       = "parameter_name"
+	MSG_MISSING_VALUE        = "missing_value"
+	MSG_MISSING_ASSIGN       = "missing_assignment"
+	MSG_TYPE_MISMATCH        = "type_mismatch"
+	MSG_ASSIGNMENT_TYPE      = "assignment_type"
+	MSG_ARGUMENT_TYPE        = "argument_type"
+	MSG_RETURN_TYPE          = "return_type"
+	MSG_EXPECTED_ARGUMENT    = "expected_argument"
+	MSG_EXPECTED_ARGS        = "expected_arguments"
+	MSG_EXPECTED_STRING      = "expected_string"
+	MSG_ARGUMENT_MUST        = "argument_must"
+	MSG_ARGUMENT_N_MUST      = "argument_n_must"
+	MSG_ARGUMENTS_MUST       = "arguments_must"
+	MSG_CANNOT_ADD           = "cannot_add"
+	MSG_CANNOT_ITERATE       = "cannot_iterate"
+	MSG_FROZEN_VALUE         = "frozen_value"
+	MSG_FROZEN_ARRAY         = "frozen_array"
+	MSG_FROZEN_FIELD         = "frozen_field"
+	MSG_NOT_A_FUNCTION       = "not_a_function"
+	MSG_ELEMENT_TYPE         = "element_type"
+	MSG_AT_LEAST_ARGUMENT    = "at_least_argument"
+	MSG_AT_LEAST_ARGS        = "at_least_arguments"
+	MSG_DENIED_READ          = "denied_read"
+	MSG_DENIED_WRITE         = "denied_write"
+	MSG_DENIED_PATH          = "denied_path"
+	MSG_DENIED_ENV           = "denied_environment"
+	MSG_ASSERTION            = "assertion"
+	MSG_ASSERTION_MESSAGE    = "assertion_message"
+	MSG_ASSERT_EQUAL         = "assert_equal"
+	MSG_ASSERT_EQUAL_MSG     = "assert_equal_message"
+	MSG_WRITE_FILE           = "write_file"
+	MSG_FIND_FILE            = "find_file"
+	MSG_LIST_DIRECTORY       = "list_directory"
+	MSG_INVALID_PATTERN      = "invalid_pattern"
+	MSG_TEST_EXIT            = "test_exit"
+	MSG_ARRAY_NOT_CLOSED     = "array_not_closed"
+	MSG_ARRAY_DIMENSION      = "array_dimension"
+	MSG_ARRAY_SIZE           = "array_size"
+	MSG_EMPTY_INDEX          = "empty_index"
+	MSG_NOT_AN_ARRAY         = "not_an_array"
+	MSG_NOT_INDEXABLE        = "not_indexable"
+	MSG_INDEX_TYPE           = "index_type"
+	MSG_INDEX_INT            = "index_int"
+	MSG_INDEXES_INT          = "indexes_int"
+	MSG_INVALID_RANGE        = "invalid_range"
+	MSG_POP_EMPTY            = "pop_empty"
+	MSG_EXPECTED_CATCH       = "expected_catch"
+	MSG_ONE_CATCH            = "one_catch"
+	MSG_MISSING_FUNCTION     = "missing_function_name"
+	MSG_PARAMETERS_START     = "parameters_start"
+	MSG_EXPECTED_TYPE        = "expected_parameter_type"
+	MSG_PARAMETER_TYPE       = "parameter_type"
+	MSG_EXPECTED_PARAMETER   = "expected_parameter_name"
+	MSG_EXPECTED_COMMA       = "expected_comma"
+	MSG_EXPECTED_CLOSING     = "expected_closing"
+	MSG_MISSING_NAME         = "missing_variable_name"
+	MSG_MISSING_NEW_NAME     = "missing_new_name"
+	MSG_INVALID_ASSIGNMENT   = "invalid_assignment"
+	MSG_EMPTY_VALUE          = "empty_value"
+	MSG_NAME_IN_USE          = "name_in_use"
+	MSG_TOO_MANY_ARGS        = "too_many_arguments"
+	MSG_MISSING_PARENTHESES  = "missing_parentheses"
+	MSG_MISSING_CALL_CLOSING = "missing_call_closing"
+	MSG_IMPROPER_DOT         = "improper_dot"
+	MSG_NUMBER_FORMAT        = "number_format"
+	MSG_INVALID_EXPRESSION   = "invalid_expression"
+	MSG_EMPTY_EXPRESSION     = "empty_expression"
+	MSG_INVALID_OPERATOR     = "invalid_operator"
+	MSG_EXPECTED_ARGS_RANGE  = "expected_arguments_range"
+	MSG_CALLED_ON            = "called_on"
+	MSG_FAILED               = "failed"
+	MSG_READ_INPUT           = "read_input"
+	MSG_END_OF_INPUT         = "end_of_input"
+	MSG_INVALID_INT          = "invalid_int"
+	MSG_NOT_A_NUMBER         = "not_a_number"
+	MSG_SINGLE_CHARACTER     = "single_character"
+	MSG_CODE_POINT           = "code_point"
+	MSG_AT_LEAST_NUMBER      = "at_least_number"
+	MSG_BOUNDS               = "bounds"
+	MSG_BOUND_VALUES         = "bound_values"
+	MSG_RANGE_TOO_LARGE      = "range_too_large"
+	MSG_INT_OVERFLOW         = "int_overflow"
+	MSG_CHOOSE_EMPTY         = "choose_empty"
+	MSG_SAMPLE_SIZE          = "sample_size"
+	MSG_POSITIVE_DEVIATION   = "positive_deviation"
+	MSG_POSITIVE_RATE        = "positive_rate"
+	MSG_POSITIVE_INDENT      = "positive_indentation"
+	MSG_POSITIVE_DURATION    = "positive_duration"
+	MSG_INVALID_JSON         = "invalid_json"
+	MSG_INVALID_CSV          = "invalid_csv"
+	MSG_CSV_HEADER           = "csv_header"
+	MSG_CSV_ROW              = "csv_row"
+	MSG_INVALID_REGEX        = "invalid_regex"
+	MSG_INVALID_REGEX_AT     = "invalid_regex_at"
+	MSG_FILE_NOT_FOUND       = "file_not_found"
+	MSG_FILE_DENIED          = "file_denied"
+	MSG_FILE_ACCESS          = "file_access"
+	MSG_FILE_ACCESS_REASON   = "file_access_reason"
+	MSG_INVALID_ENV          = "invalid_environment"
+	MSG_EXIT_CODE            = "exit_code"
+	MSG_ZONE_STRING          = "zone_string"
+	MSG_UNKNOWN_ZONE         = "unknown_zone"
+	MSG_INVALID_DATE         = "invalid_date"
+	MSG_INVALID_LAYOUT       = "invalid_layout"
+	MSG_PARSE_TIME           = "parse_time"
+	MSG_PARSE_LAYOUT         = "parse_layout"
+)
+
+// Catalogue of the messages by identifier, then by language. "{0}", "{1}"... are replaced by the arguments of the message.
+var MESSAGES = map[string]map[string]string{
+	MSG_ERROR:                {"en": "Error: {0}", "fr": "Erreur : {0}"},
+	MSG_IN_FUNCTION:          {"en": "In function \"{0}\"", "fr": "Dans la fonction \"{0}\""},
+	MSG_ON_LINE:              {"en": "on line {0}", "fr": "à la ligne {0}"},
+	MSG_COLUMN:               {"en": ", column {0}", "fr": ", colonne {0}"},
+	MSG_AT:                   {"en": "at {0}", "fr": "à {0}"},
+	MSG_CALL_STACK:           {"en": "Call stack (most recent call last):", "fr": "Pile d'appels (le plus récent en dernier) :"},
+	MSG_ELIDED_FRAME:         {"en": "... {0} more frame", "fr": "... {0} autre appel"},
+	MSG_ELIDED_FRAMES:        {"en": "... {0} more frames", "fr": "... {0} autres appels"},
+	MSG_ELIDED_FRAME_OF:      {"en": "... {0} more frame of {1}", "fr": "... {0} autre appel de {1}"},
+	MSG_ELIDED_FRAMES_OF:     {"en": "... {0} more frames of {1}", "fr": "... {0} autres appels de {1}"},
+	MSG_DID_YOU_MEAN:         {"en": "Did you mean \"{0}\"?", "fr": "Vouliez-vous dire \"{0}\" ?"},
+	MSG_DID_YOU_MEAN_END:     {"en": "Did you mean \"end {0}\" instead of \"end {1}\" on line {2}?", "fr": "Vouliez-vous dire \"end {0}\" au lieu de \"end {1}\" à la ligne {2} ?"},
+	MSG_READ_FILE:            {"en": "Could not find and read the file \"{0}\"", "fr": "Impossible de trouver et de lire le fichier \"{0}\""},
+	MSG_READ_STANDARD_IN:     {"en": "Could not read the standard input", "fr": "Impossible de lire l'entrée standard"},
+	MSG_UNKNOWN_NAME:         {"en": "Unknown variable or function \"{0}\"", "fr": "Variable ou fonction inconnue \"{0}\""},
+	MSG_UNKNOWN_VARIABLE:     {"en": "Unknown variable \"{0}\"", "fr": "Variable inconnue \"{0}\""},
+	MSG_NOT_IN_FUNCTION:      {"en": "Variable '{0}' does not exist in the function", "fr": "La variable '{0}' n'existe pas dans la fonction"},
+	MSG_UNDEFINED:            {"en": "Variable '{0}' does not exist", "fr": "La variable '{0}' n'existe pas"},
+	MSG_UNKNOWN_FIELD:        {"en": "The object has no field \"{0}\"", "fr": "L'objet n'a pas de champ \"{0}\""},
+	MSG_UNKNOWN_METHOD:       {"en": "Unknown method '{0}' for type ({1})", "fr": "Méthode inconnue '{0}' pour le type ({1})"},
+	MSG_UNKNOWN_COMMAND:      {"en": "Unknown command \"{0}\"", "fr": "Commande inconnue \"{0}\""},
+	MSG_UNKNOWN_KEYWORDS:     {"en": "Unknown language \"{0}\" for the keywords", "fr": "Langue inconnue \"{0}\" pour les mots-clés"},
+	MSG_UNCLOSED_BLOCK:       {"en": "Block \"{0}\" not closed with \"end {1}\"", "fr": "Bloc \"{0}\" non fermé par \"end {1}\""},
+	MSG_UNEXPECTED:           {"en": "Unexpected \"{0}\"", "fr": "\"{0}\" inattendu"},
+	MSG_UNEXPECTED_AFTER:     {"en": "Unexpected \"{0}\" after \"{1}\"", "fr": "\"{0}\" inattendu après \"{1}\""},
+	MSG_UNEXPECTED_END:       {"en": "Unexpected \"end\" outside of a block", "fr": "\"end\" inattendu en dehors d'un bloc"},
+	MSG_UNEXPECTED_BLOCK_END: {"en": "Unexpected \"end {0}\"", "fr": "\"end {0}\" inattendu"},
+	MSG_UNEXPECTED_ELSE:      {"en": "Unexpected \"else\" outside of an \"if\" block", "fr": "\"else\" inattendu en dehors d'un bloc \"if\""},
+	MSG_UNEXPECTED_CATCH:     {"en": "Unexpected \"catch\" outside of a \"try\" block", "fr": "\"catch\" inattendu en dehors d'un bloc \"try\""},
+	MSG_MISMATCHED_END:       {"en": "Expected \"end {0}\" instead of \"end {1}\"", "fr": "\"end {0}\" attendu au lieu de \"end {1}\""},
+	MSG_MISSING_END_NAME:     {"en": "Expected the name of the block after \"end\"", "fr": "Nom du bloc attendu après \"end\""},
+	MSG_BREAK_OUTSIDE:        {"en": "\"break\" outside of a loop", "fr": "\"break\" en dehors d'une boucle"},
+	MSG_MISSING_CONDITION:    {"en": "Missing condition after \"{0}\"", "fr": "Condition manquante après \"{0}\""},
+	MSG_MISSING_AFTER:        {"en": "Missing a value after \"{0}\"", "fr": "Valeur manquante après \"{0}\""},
+	MSG_MISSING_SIDE:         {"en": "Missing a value on one side of \"{0}\"", "fr": "Valeur manquante d'un côté de \"{0}\""},
+	MSG_MISSING_OPENING:      {"en": "Missing an opening parenthesis for \"{0}\"", "fr": "Parenthèse ouvrante manquante pour \"{0}\""},
+	MSG_MISSING_CLOSING:      {"en": "Missing closing parenthesis", "fr": "Parenthèse fermante manquante"},
+	MSG_MISSING_BRACKET:      {"en": "Missing closing bracket", "fr": "Crochet fermant manquant"},
+	MSG_MISSING_QUOTE:        {"en": "Missing closing quote for string", "fr": "Guillemet fermant manquant pour la chaîne"},
+	MSG_UNCLOSED_STRING:      {"en": "Unclosed string", "fr": "Chaîne non fermée"},
+	MSG_DIVIDE_BY_ZERO:       {"en": "Cannot divide by zero", "fr": "Division par zéro impossible"},
+	MSG_MODULO_BY_ZERO:       {"en": "Modulo by zero", "fr": "Modulo par zéro"},
+	MSG_INVALID_OPERATION:    {"en": "Invalid type ({0} {1} {2}) operation with {3}", "fr": "Types invalides ({0} {1} {2}) pour l'opération {3}"},
+	MSG_INVALID_NEGATION:     {"en": "Invalid type ({0}) operation with negation", "fr": "Type invalide ({0}) pour la négation"},
+	MSG_CANNOT_COMPARE:       {"en": "Cannot compare {0} with {1} type", "fr": "Impossible de comparer le type {0} avec le type {1}"},
+	MSG_CONDITION_BOOL:       {"en": "Condition must be a boolean", "fr": "La condition doit être un booléen"},
+	MSG_CONDITION_TYPE:       {"en": "Invalid condition type \"{0}\"", "fr": "Type de condition invalide \"{0}\""},
+	MSG_RECURSION_LIMIT:      {"en": "Recursion limit reached ({0})", "fr": "Limite de récursion atteinte ({0})"},
+	MSG_INDEX_OUT:            {"en": "Index {0} out of bounds for length {1}", "fr": "Indice {0} hors limites pour une longueur de {1}"},
+	MSG_CONSTANT:             {"en": "Cannot assign to constant \"{0}\"", "fr": "Impossible de modifier la constante \"{0}\""},
+	MSG_VARIABLE_EXISTS:      {"en": "Variable \"{0}\" already exists in the current scope", "fr": "La variable \"{0}\" existe déjà dans la portée courante"},
+	MSG_VARIABLE_NAME:        {"en": "Variable names must be alphanumeric and start with a letter. Invalid variable name \"{0}\"", "fr": "Les noms de variables doivent être alphanumériques et commencer par une lettre. Nom de variable invalide \"{0}\""},
+	MSG_FUNCTION_NAME:        {"en": "The function name must be alphanumeric. Invalid function name \"{0}\"", "fr": "Le nom de la fonction doit être alphanumérique. Nom de fonction invalide \"{0}\""},
+	MSG_PARAMETER_NAME:       {"en": "The parameter name must be alphanumeric. Invalid parameter name \"{0}\"", "fr": "Le nom du paramètre doit être alphanumérique. Nom de paramètre invalide \"{0}\""},
+	MSG_MISSING_VALUE:        {"en": "Missing value for variable \"{0}\"", "fr": "Valeur manquante pour la variable \"{0}\""},
+	MSG_MISSING_ASSIGN:       {"en": "Missing assignment for variable \"{0}\"", "fr": "Affectation manquante pour la variable \"{0}\""},
+	MSG_TYPE_MISMATCH:        {"en": "Variable \"{0}\" cannot be assigned to type \"{1}\"", "fr": "La variable \"{0}\" ne peut pas recevoir le type \"{1}\""},
+	MSG_ASSIGNMENT_TYPE:      {"en": "Expected type {0} but got type {1}. Invalid assignment type \"{1}\"", "fr": "Type {0} attendu mais type {1} obtenu. Type d'affectation invalide \"{1}\""},
+	MSG_ARGUMENT_TYPE:        {"en": "Argument type mismatch for the argument \"{0}\"", "fr": "Type incorrect pour l'argument \"{0}\""},
+	MSG_RETURN_TYPE:          {"en": "Invalid return type \"{0}\"", "fr": "Type de retour invalide \"{0}\""},
+	MSG_EXPECTED_ARGUMENT:    {"en": "Expected {0} argument for \"{1}\"", "fr": "{0} argument attendu pour \"{1}\""},
+	MSG_EXPECTED_ARGS:        {"en": "Expected {0} arguments for \"{1}\"", "fr": "{0} arguments attendus pour \"{1}\""},
+	MSG_EXPECTED_STRING:      {"en": "Expected 1 string argument for \"{0}\"", "fr": "1 argument de type chaîne attendu pour \"{0}\""},
+	MSG_ARGUMENT_MUST:        {"en": "Argument must be {0} for \"{1}\"", "fr": "L'argument doit être {0} pour \"{1}\""},
+	MSG_ARGUMENT_N_MUST:      {"en": "Argument {0} must be {1} for \"{2}\"", "fr": "L'argument {0} doit être {1} pour \"{2}\""},
+	MSG_ARGUMENTS_MUST:       {"en": "Arguments must be {0} for \"{1}\"", "fr": "Les arguments doivent être {0} pour \"{1}\""},
+	MSG_CANNOT_ADD:           {"en": "Cannot add values of type ({0}) and ({1})", "fr": "Impossible d'additionner des valeurs de type ({0}) et ({1})"},
+	MSG_CANNOT_ITERATE:       {"en": "Cannot iterate over a value of type ({0})", "fr": "Impossible de parcourir une valeur de type ({0})"},
+	MSG_FROZEN_VALUE:         {"en": "Cannot modify an element of frozen value \"{0}\"", "fr": "Impossible de modifier un élément de la valeur gelée \"{0}\""},
+	MSG_FROZEN_ARRAY:         {"en": "Cannot modify a frozen array with \"{0}\"", "fr": "Impossible de modifier un tableau gelé avec \"{0}\""},
+	MSG_FROZEN_FIELD:         {"en": "Cannot assign to field \"{0}\" of a frozen object", "fr": "Impossible de modifier le champ \"{0}\" d'un objet gelé"},
+	MSG_NOT_A_FUNCTION:       {"en": "Variable '{0}' is not a function", "fr": "La variable '{0}' n'est pas une fonction"},
+	MSG_ELEMENT_TYPE:         {"en": "Expected an element of type {0} but got type {1} for \"{2}\"", "fr": "Élément de type {0} attendu mais type {1} obtenu pour \"{2}\""},
+	MSG_AT_LEAST_ARGUMENT:    {"en": "Expected at least {0} argument for \"{1}\"", "fr": "Au moins {0} argument attendu pour \"{1}\""},
+	MSG_AT_LEAST_ARGS:        {"en": "Expected at least {0} arguments for \"{1}\"", "fr": "Au moins {0} arguments attendus pour \"{1}\""},
+	MSG_DENIED_READ:          {"en": "Permission denied to read \"{0}\" for \"{1}\"", "fr": "Permission refusée pour lire \"{0}\" avec \"{1}\""},
+	MSG_DENIED_WRITE:         {"en": "Permission denied to write \"{0}\" for \"{1}\"", "fr": "Permission refusée pour écrire \"{0}\" avec \"{1}\""},
+	MSG_DENIED_PATH:          {"en": "Permission denied to access \"{0}\" outside of the allowed paths for \"{1}\"", "fr": "Permission refusée pour accéder à \"{0}\" en dehors des chemins autorisés avec \"{1}\""},
+	MSG_DENIED_ENV:           {"en": "Permission denied to access the environment for \"{0}\"", "fr": "Permission refusée pour accéder à l'environnement avec \"{0}\""},
+	MSG_ASSERTION:            {"en": "Assertion failed", "fr": "Échec de l'assertion"},
+	MSG_ASSERTION_MESSAGE:    {"en": "Assertion failed: {0}", "fr": "Échec de l'assertion : {0}"},
+	MSG_ASSERT_EQUAL:         {"en": "Expected {0} ({1}) but got {2} ({3})", "fr": "{0} ({1}) attendu mais {2} ({3}) obtenu"},
+	MSG_ASSERT_EQUAL_MSG:     {"en": "Expected {0} ({1}) but got {2} ({3}): {4}", "fr": "{0} ({1}) attendu mais {2} ({3}) obtenu : {4}"},
+	MSG_WRITE_FILE:           {"en": "Could not write the file \"{0}\"", "fr": "Impossible d'écrire le fichier \"{0}\""},
+	MSG_FIND_FILE:            {"en": "Could not find the file \"{0}\"", "fr": "Impossible de trouver le fichier \"{0}\""},
+	MSG_LIST_DIRECTORY:       {"en": "Could not list the directory \"{0}\"", "fr": "Impossible de lister le répertoire \"{0}\""},
+	MSG_INVALID_PATTERN:      {"en": "Invalid pattern \"{0}\" for \"{1}\"", "fr": "Motif invalide \"{0}\" pour \"{1}\""},
+	MSG_TEST_EXIT:            {"en": "The test exited with status {0}", "fr": "Le test s'est terminé avec le statut {0}"},
+	MSG_ARRAY_NOT_CLOSED:     {"en": "Array not closed", "fr": "Tableau non fermé"},
+	MSG_ARRAY_DIMENSION:      {"en": "Invalid array dimension at declaration", "fr": "Dimension de tableau invalide à la déclaration"},
+	MSG_ARRAY_SIZE:           {"en": "Cannot get array size of non-array type", "fr": "Impossible d'obtenir la taille d'un type qui n'est pas un tableau"},
+	MSG_EMPTY_INDEX:          {"en": "Cannot access index {0} of an empty array or string", "fr": "Impossible d'accéder à l'indice {0} d'un tableau ou d'une chaîne vide"},
+	MSG_NOT_AN_ARRAY:         {"en": "\"{0}\" cannot access that index because it might not be an array", "fr": "\"{0}\" ne peut pas accéder à cet indice car ce n'est peut-être pas un tableau"},
+	MSG_NOT_INDEXABLE:        {"en": "'{0}' cannot access that index because it might not be an array or a string", "fr": "'{0}' ne peut pas accéder à cet indice car ce n'est peut-être ni un tableau ni une chaîne"},
+	MSG_INDEX_TYPE:           {"en": "Invalid array index type \"{0}\"", "fr": "Type d'indice de tableau invalide \"{0}\""},
+	MSG_INDEX_INT:            {"en": "Array index must be an integer", "fr": "L'indice du tableau doit être un entier"},
+	MSG_INDEXES_INT:          {"en": "Indexes must be ints for \"{0}\"", "fr": "Les indices doivent être des entiers pour \"{0}\""},
+	MSG_INVALID_RANGE:        {"en": "Invalid range [{0}, {1}) for length {2} for \"{3}\"", "fr": "Intervalle invalide [{0}, {1}) pour une longueur de {2} pour \"{3}\""},
+	MSG_POP_EMPTY:            {"en": "Cannot pop from an empty array", "fr": "Impossible de retirer un élément d'un tableau vide"},
+	MSG_EXPECTED_CATCH:       {"en": "Expected \"catch\" or \"catch <name>\"", "fr": "\"catch\" ou \"catch <nom>\" attendu"},
+	MSG_ONE_CATCH:            {"en": "Only one \"catch\" is allowed per \"try\"", "fr": "Un seul \"catch\" est permis par \"try\""},
+	MSG_MISSING_FUNCTION:     {"en": "Function name not provided", "fr": "Nom de fonction manquant"},
+	MSG_PARAMETERS_START:     {"en": "Function parameters must start with a parentheses", "fr": "Les paramètres de la fonction doivent commencer par une parenthèse"},
+	MSG_EXPECTED_TYPE:        {"en": "Expected a parameter type", "fr": "Type de paramètre attendu"},
+	MSG_PARAMETER_TYPE:       {"en": "Invalid parameter type \"{0}\"", "fr": "Type de paramètre invalide \"{0}\""},
+	MSG_EXPECTED_PARAMETER:   {"en": "Expected a parameter name", "fr": "Nom de paramètre attendu"},
+	MSG_EXPECTED_COMMA:       {"en": "Expected a comma or a closing parenthesis", "fr": "Virgule ou parenthèse fermante attendue"},
+	MSG_EXPECTED_CLOSING:     {"en": "Expected a closing parenthesis", "fr": "Parenthèse fermante attendue"},
+	MSG_MISSING_NAME:         {"en": "Missing variable name", "fr": "Nom de variable manquant"},
+	MSG_MISSING_NEW_NAME:     {"en": "Missing variable name after 'new'", "fr": "Nom de variable manquant après 'new'"},
+	MSG_INVALID_ASSIGNMENT:   {"en": "Invalid assignment to \"{0}\"", "fr": "Affectation invalide à \"{0}\""},
+	MSG_EMPTY_VALUE:          {"en": "Variable value cannot be empty", "fr": "La valeur de la variable ne peut pas être vide"},
+	MSG_NAME_IN_USE:          {"en": "The function/variable name \"{0}\" is already in use", "fr": "Le nom de fonction ou de variable \"{0}\" est déjà utilisé"},
+	MSG_TOO_MANY_ARGS:        {"en": "Argument count (expected at most {0}) mismatch for \"{1}\"", "fr": "Nombre d'arguments incorrect (au plus {0} attendus) pour \"{1}\""},
+	MSG_MISSING_PARENTHESES:  {"en": "Missing parentheses for the function \"{0}\"", "fr": "Parenthèses manquantes pour la fonction \"{0}\""},
+	MSG_MISSING_CALL_CLOSING: {"en": "Missing closing parentheses for the function call \"{0}\"", "fr": "Parenthèse fermante manquante pour l'appel de la fonction \"{0}\""},
+	MSG_IMPROPER_DOT:         {"en": "Improper use of '.'", "fr": "Utilisation incorrecte de '.'"},
+	MSG_NUMBER_FORMAT:        {"en": "Invalid number format", "fr": "Format de nombre invalide"},
+	MSG_INVALID_EXPRESSION:   {"en": "Invalid expression \"{0}\"", "fr": "Expression invalide \"{0}\""},
+	MSG_EMPTY_EXPRESSION:     {"en": "Empty expression", "fr": "Expression vide"},
+	MSG_INVALID_OPERATOR:     {"en": "Invalid operator ({0})", "fr": "Opérateur invalide ({0})"},
+	MSG_EXPECTED_ARGS_RANGE:  {"en": "Expected {0} to {1} arguments for \"{2}\"", "fr": "De {0} à {1} arguments attendus pour \"{2}\""},
+	MSG_CALLED_ON:            {"en": "\"{0}\" must be called on {1}", "fr": "\"{0}\" doit être appelé sur {1}"},
+	MSG_FAILED:               {"en": "{0} for \"{1}\"", "fr": "{0} pour \"{1}\""},
+	MSG_READ_INPUT:           {"en": "Unable to read the input ({0}) for \"{1}\"", "fr": "Impossible de lire l'entrée ({0}) avec \"{1}\""},
+	MSG_END_OF_INPUT:         {"en": "Unexpected end of input for \"{0}\"", "fr": "Fin de l'entrée inattendue pour \"{0}\""},
+	MSG_INVALID_INT:          {"en": "Invalid int {0} for \"{1}\"", "fr": "Entier invalide {0} pour \"{1}\""},
+	MSG_NOT_A_NUMBER:         {"en": "String is not a number or is too large to be converted to {0} for \"{1}\"", "fr": "La chaîne n'est pas un nombre ou est trop grande pour être convertie en {0} pour \"{1}\""},
+	MSG_SINGLE_CHARACTER:     {"en": "String argument must be of size 1 for \"{0}\"", "fr": "L'argument doit être une chaîne de taille 1 pour \"{0}\""},
+	MSG_CODE_POINT:           {"en": "Invalid code point {0} for \"{1}\"", "fr": "Point de code invalide {0} pour \"{1}\""},
+	MSG_AT_LEAST_NUMBER:      {"en": "Expected at least 1 number for \"{0}\"", "fr": "Au moins 1 nombre attendu pour \"{0}\""},
+	MSG_BOUNDS:               {"en": "The lower bound is greater than the upper bound for \"{0}\"", "fr": "La borne inférieure est plus grande que la borne supérieure pour \"{0}\""},
+	MSG_BOUND_VALUES:         {"en": "The lower bound {0} is greater than the upper bound {1} for \"{2}\"", "fr": "La borne inférieure {0} est plus grande que la borne supérieure {1} pour \"{2}\""},
+	MSG_RANGE_TOO_LARGE:      {"en": "Range is too large for \"{0}\"", "fr": "Intervalle trop grand pour \"{0}\""},
+	MSG_INT_OVERFLOW:         {"en": "The result of \"{0}\" is too large for an int", "fr": "Le résultat de \"{0}\" est trop grand pour un int"},
+	MSG_CHOOSE_EMPTY:         {"en": "Cannot choose from an empty array", "fr": "Impossible de choisir dans un tableau vide"},
+	MSG_SAMPLE_SIZE:          {"en": "Cannot sample {0} elements from an array of length {1}", "fr": "Impossible de tirer {0} éléments d'un tableau de longueur {1}"},
+	MSG_POSITIVE_DEVIATION:   {"en": "The standard deviation must be positive for \"{0}\"", "fr": "L'écart type doit être positif pour \"{0}\""},
+	MSG_POSITIVE_RATE:        {"en": "The rate must be greater than 0 for \"{0}\"", "fr": "Le taux doit être supérieur à 0 pour \"{0}\""},
+	MSG_POSITIVE_INDENT:      {"en": "The indentation must be positive for \"{0}\"", "fr": "L'indentation doit être positive pour \"{0}\""},
+	MSG_POSITIVE_DURATION:    {"en": "The duration must be positive for \"{0}\"", "fr": "La durée doit être positive pour \"{0}\""},
+	MSG_INVALID_JSON:         {"en": "Invalid JSON at line {0}, column {1}: {2} for \"{3}\"", "fr": "JSON invalide à la ligne {0}, colonne {1} : {2} pour \"{3}\""},
+	MSG_INVALID_CSV:          {"en": "Invalid CSV in {0} at line {1}, column {2}: {3} for \"{4}\"", "fr": "CSV invalide dans {0} à la ligne {1}, colonne {2} : {3} pour \"{4}\""},
+	MSG_CSV_HEADER:           {"en": "The header must be an array of strings for \"{0}\"", "fr": "L'en-tête doit être un tableau de chaînes pour \"{0}\""},
+	MSG_CSV_ROW:              {"en": "Row {0} must be an array or a record for \"{1}\"", "fr": "La ligne {0} doit être un tableau ou un enregistrement pour \"{1}\""},
+	MSG_INVALID_REGEX:        {"en": "Invalid regular expression {0}: {1}", "fr": "Expression régulière invalide {0} : {1}"},
+	MSG_INVALID_REGEX_AT:     {"en": "Invalid regular expression {0} at position {1}: {2}", "fr": "Expression régulière invalide {0} à la position {1} : {2}"},
+	MSG_FILE_NOT_FOUND:       {"en": "No such file or directory \"{0}\" for \"{1}\"", "fr": "Fichier ou répertoire introuvable \"{0}\" pour \"{1}\""},
+	MSG_FILE_DENIED:          {"en": "Permission denied for \"{0}\" for \"{1}\"", "fr": "Permission refusée pour \"{0}\" avec \"{1}\""},
+	MSG_FILE_ACCESS:          {"en": "Unable to access \"{0}\" for \"{1}\"", "fr": "Impossible d'accéder à \"{0}\" avec \"{1}\""},
+	MSG_FILE_ACCESS_REASON:   {"en": "Unable to access ({0}) \"{1}\" for \"{2}\"", "fr": "Impossible d'accéder ({0}) à \"{1}\" avec \"{2}\""},
+	MSG_INVALID_ENV:          {"en": "Invalid environment variable {0} for \"{1}\"", "fr": "Variable d'environnement invalide {0} pour \"{1}\""},
+	MSG_EXIT_CODE:            {"en": "The exit code must be between 0 and 255 for \"{0}\"", "fr": "Le code de sortie doit être entre 0 et 255 pour \"{0}\""},
+	MSG_ZONE_STRING:          {"en": "The time zone must be a string for \"{0}\"", "fr": "Le fuseau horaire doit être une chaîne pour \"{0}\""},
+	MSG_UNKNOWN_ZONE:         {"en": "Unknown time zone {0} for \"{1}\"", "fr": "Fuseau horaire inconnu {0} pour \"{1}\""},
+	MSG_INVALID_DATE:         {"en": "Invalid date for \"{0}\"", "fr": "Date invalide pour \"{0}\""},
+	MSG_INVALID_LAYOUT:       {"en": "Invalid layout {0} for \"{1}\"", "fr": "Format invalide {0} pour \"{1}\""},
+	MSG_PARSE_TIME:           {"en": "Cannot read {0} as a datetime for \"{1}\"", "fr": "Impossible de lire {0} comme une date pour \"{1}\""},
+	MSG_PARSE_LAYOUT:         {"en": "Cannot read {0} as a datetime with the layout {1} for \"{2}\"", "fr": "Impossible de lire {0} comme une date avec le format {1} pour \"{2}\""},
+}
+
+// Translations of the words found in the arguments of the messages (e.g. the type of "Argument must be a string")
+var MESSAGE_WORDS = map[string]map[string]string{
+	"fr": {
+		"or":                   "ou",
+		"a string":             "une chaîne",
+		"a bool":               "un booléen",
+		"an int":               "un entier",
+		"a positive int":       "un entier positif",
+		"an integer":           "un entier",
+		"a positive float":     "un réel positif",
+		"a non-empty string":   "une chaîne non vide",
+		"an array of rows":     "un tableau de lignes",
+		"a CSV reader":         "un lecteur CSV",
+		"a regular expression": "une expression régulière",
+		"a float":              "un réel",
+		"a number":             "un nombre",
+		"an array":             "un tableau",
+		"an object":            "un objet",
+		"a datetime":           "une date",
+		"ints":                 "des entiers",
+		"floats":               "des réels",
+		"strings":              "des chaînes",
+		"addition":             "addition",
+		"subtraction":          "soustraction",
+		"multiplication":       "multiplication",
+		"division":             "division",
+		"exponent":             "puissance",
+		"modulo":               "modulo",
+	},
+}
+
+// ! Message : A message of the catalogue with its arguments, kept so that it can be shown in any language.
+// -------------------------
+// ! ID : The identifier of the message (e.g. MSG_UNKNOWN_NAME).
+// -------------------------
+// ! Arguments : The arguments replacing the placeholders of the message.
+// -------------------------
+// ! IsError : True if the message is prefixed by "Error: ".
+type Message struct {
+	ID        string
+	Arguments []string
+	IsError   bool
+}
+
+// Placeholders of the arguments of the messages, e.g. {0}
+var placeholderFormat = regexp.MustCompile(`\{([0-9]+)\}`)
+
+/**
+ * Create a message of the catalogue.
+ * @param id : string - The identifier of the message (e.g. MSG_IN_FUNCTION).
+ * @param args : ...string - The arguments of the message.
+ * @return Message - The message.
+ */
+func NewMessage(id string, args ...string) Message {
+	return Message{ID: id, Arguments: args}
+}
+
+/**
+ * Create the message of an error of the catalogue, e.g. Error: Cannot divide by zero.
+ * @param id : string - The identifier of the message (e.g. MSG_DIVIDE_BY_ZERO).
+ * @param args : ...string - The arguments of the message.
+ * @return Message - The message, prefixed by "Error: " when it is shown.
+ */
+func ErrorMessage(id string, args ...string) Message {
+	return Message{ID: id, Arguments: args, IsError: true}
+}
+
+/**
+ * Get the message in English, the language the errors are created in.
+ * @return string - The message, or an empty string for an empty message.
+ */
+func (message Message) String() string {
+	return message.In("en")
+}
+
+/**
+ * Get the message in a language. The words of the arguments are translated (see MESSAGE_WORDS), except the ones
+ * between quotes in the message (e.g. names).
+ * @param language : string - The language (see LANGUAGES). The message is in English if it is not translated.
+ * @return string - The message, or an empty string for an empty message.
+ */
+func (message Message) In(language string) string {
+	if message.ID == "" {
+		return ""
+	}
+
+	english := MESSAGES[message.ID]["en"]
+	args := make([]string, len(message.Arguments))
+	for i, argument := range message.Arguments {
+		args[i] = argument
+		if placeholder := strings.Index(english, "{"+strconv.Itoa(i)+"}"); placeholder <= 0 || !strings.ContainsAny(english[placeholder-1:placeholder], `"'`) {
+			args[i] = translateWords(argument, language)
+		}
+	}
+
+	txt := Text(language, message.ID, args...)
+	if message.IsError {
+		txt = Text(language, MSG_ERROR, txt)
+	}
+	return txt
+}
+
+/**
+ * Get a message of the catalogue in a language.
+ * @param language : string - The language (see LANGUAGES). The message is in English if it is not translated.
+ * @param id : string - The identifier of the message.
+ * @param args : ...string - The arguments of the message.
+ * @return string - The message.
+ */
+func Text(language string, id string, args ...string) string {
+	template, found := MESSAGES[id][language]
+	if !found {
+		template = MESSAGES[id]["en"]
+	}
+	return placeholderFormat.ReplaceAllStringFunc(template, func(placeholder string) string {
+		index, _ := strconv.Atoi(placeholder[1 : len(placeholder)-1])
+		if index < len(args) {
+			return args[index]
+		}
+		return placeholder
+	})
+}
+
+/**
+ * Show the messages, hints, locations and call stacks of the errors of the stack in a language.
+ * @param e *ErrorStack - The error stack.
+ * @param language : string - The language (see LANGUAGES).
+**/
+func (e *ErrorStack) Localize(language string) {
+	for ; e != nil; e = (*e).NextError {
+		if (*e).text.ID != "" {
+			(*e).Message = (*e).text.In(language)
+		}
+		if (*e).hint.ID != "" {
+			(*e).Hint = (*e).hint.In(language)
+		}
+		(*e).language = language
+	}
+}
+
+/**
+ * Get the language of a locale, e.g. "fr" for "fr_CA.UTF-8".
+ * @param locale : string - The locale (e.g. the LANG environment variable) or a language.
+ * @return string - The language if it is supported (see LANGUAGES), or an empty string.
+ */
+func FindLanguage(locale string) string {
+	language := strings.ToLower(locale)
+	if end := strings.IndexAny(language, "_.-@"); end >= 0 {
+		language = language[:end]
+	}
+	for _, supported := range LANGUAGES {
+		if language == supported {
+			return language
+		}
+	}
+	return ""
+}
+
+/**
+ * Remove the "Error: " prefix of a message, in any language.
+ * @param message : string - The message.
+ * @return string - The message without its prefix.
+ */
+func trimErrorPrefix(message string) string {
+	for _, language := range LANGUAGES {
+		if prefix := Text(language, MSG_ERROR, ""); strings.HasPrefix(message, prefix) {
+			return strings.TrimPrefix(message, prefix)
+		}
+	}
+	return message
+}
+
+/**
+ * Translate the words of an argument of a message, e.g. "une chaîne ou un tableau" for "a string or an array".
+ * @param argument : string - The argument.
+ * @param language : string - The language.
+ * @return string - The translated argument. The unknown words are kept as is.
+ */
+func translateWords(argument string, language string) string {
+	words := MESSAGE_WORDS[language]
+	if words == nil {
+		return argument
+	}
+
+	// An argument is only translated if each of its words is known (or is a number), so that values are kept as is
+	parts := strings.Split(argument, " or ")
+	for i, part := range parts {
+		if word, found := words[part]; found {
+			parts[i] = word
+		} else if strings.Trim(part, "0123456789, ") != "" {
+			return argument
+		}
+	}
+	return strings.Join(parts, " "+words["or"]+" ")
+}
@@ -0,0 +1,59 @@
+package kode
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+/**
+ * Get the placeholders of a message, e.g. [{0} {1}].
+ * @param txt : string - The text of the message.
+ * @return []string - The sorted placeholders.
+ */
+func placeholders(txt string) []string {
+	found := placeholderFormat.FindAllString(txt, -1)
+	sort.Strings(found)
+	return found
+}
+
+func TestMessagesAreTranslated(t *testing.T) {
+	for id, texts := range MESSAGES {
+		for _, language := range LANGUAGES {
+			txt, found := texts[language]
+			if !found {
+				t.Errorf("message %q has no text in %q", id, language)
+				continue
+			}
+			if !reflect.DeepEqual(placeholders(txt), placeholders(texts["en"])) {
+				t.Errorf("message %q has other placeholders in %q: %q", id, language, txt)
+			}
+		}
+	}
+}
+
+func TestErrorsAreLocalized(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{code: `datetime(2024, 2, 30)`, expected: `Erreur : Date invalide pour "datetime"`},
+		{code: `regex("a(b")`, expected: `Erreur : Expression régulière invalide "a(b" à la position 1 : missing closing )`},
+		{code: `toZone(now(), "Nowhere/X")`, expected: `Erreur : Fuseau horaire inconnu "Nowhere/X" pour "toZone"`},
+		{code: `choice([])`, expected: `Erreur : Impossible de choisir dans un tableau vide`},
+		{code: `fromUnicode("a")`, expected: `Erreur : L'argument doit être un entier pour "fromUnicode"`},
+	}
+
+	for _, test := range tests {
+		err := NewInterpreter().Run(test.code)
+		if err == nil {
+			t.Errorf("%s: expected an error", test.code)
+			continue
+		}
+		cause := err.(*ErrorStack).Cause()
+		cause.Localize("fr")
+		if cause.Message != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.code, test.expected, cause.Message)
+		}
+	}
+}
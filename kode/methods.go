@@ -0,0 +1,147 @@
+package kode
+
+// ! Method : A function callable with the dot syntax on a value (e.g. "abc".upper()).
+// The value is passed as the first argument.
+type Method func(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack)
+
+/**
+ * Create a method that calls a built-in function with the value as its first argument.
+ * @param name : string - The name of the built-in function.
+ * @return Method - The method.
+ */
+func BuiltInMethod(name string) Method {
+	return func(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+		return RunBuiltIn(scope, name, args, startLine)
+	}
+}
+
+/**
+ * Create the default methods of each type.
+ * Methods are grouped by type name: a specific type (e.g. "int[]"), "array" for every array type, or "val" for every type.
+ * @return map[string]map[string]Method - The methods by type and by name.
+ */
+func DefaultMethods() map[string]map[string]Method {
+
+	// Method name to built-in function name
+	aliases := map[string]map[string]string{
+		"val": {
+			"toString": "toString", "typeOf": "typeOf", "repr": "repr",
+			"copy": "copy", "deepCopy": "deepCopy", "freeze": "freeze",
+		},
+		"string": {
+			"len": "len", "upper": "yell", "lower": "whisper", "yell": "yell", "whisper": "whisper",
+			"toInt": "toInt", "toFloat": "toFloat", "isNumeric": "isNumeric", "isAlphaNumeric": "isAlphaNumeric",
+			"toUnicode": "toUnicode", "slice": "slice", "bytes": "bytes", "graphemes": "graphemes",
+			"split": "split", "replace": "replace", "replaceAll": "replaceAll", "indexOf": "indexOf",
+			"lastIndexOf": "lastIndexOf", "contains": "contains", "startsWith": "startsWith", "endsWith": "endsWith",
+			"trim": "trim", "trimLeft": "trimLeft", "trimRight": "trimRight", "padLeft": "padLeft",
+			"padRight": "padRight", "repeat": "repeat", "reverse": "reverse", "lines": "lines",
+			"chars": "chars", "title": "title",
+		},
+		"int": {
+			"toInt": "toInt", "toFloat": "toFloat", "abs": "abs", "sqrt": "sqrt", "round": "round",
+			"floor": "floor", "ceil": "ceil", "fromUnicode": "fromUnicode",
+		},
+		"float": {
+			"toInt": "toInt", "toFloat": "toFloat", "abs": "abs", "sqrt": "sqrt", "round": "round",
+			"floor": "floor", "ceil": "ceil", "isNaN": "isNaN", "isInf": "isInf",
+		},
+		"bool": {},
+		"array": {
+			"len": "len", "push": "push", "pop": "pop", "insert": "insert", "removeAt": "removeAt",
+			"clear": "clear", "reserve": "reserve", "extend": "extend", "append": "append",
+			"truncate": "truncate", "slice": "slice", "reverse": "reverse", "join": "join",
+			"min": "min", "max": "max", "choice": "choice", "shuffle": "shuffle", "sample": "sample",
+		},
+	}
+
+	methods := map[string]map[string]Method{}
+	for typeName, names := range aliases {
+		methods[typeName] = map[string]Method{}
+		for name, builtIn := range names {
+			methods[typeName][name] = BuiltInMethod(builtIn)
+		}
+	}
+
+	// Regular expressions only have methods
+	methods["regex"] = map[string]Method{
+		"test":    RegexTest,
+		"find":    RegexFind,
+		"findAll": RegexFindAll,
+		"groups":  RegexGroups,
+		"replace": RegexReplace,
+		"split":   RegexSplit,
+	}
+
+	// CSV readers returned by "openCSV"
+	methods["csv"] = map[string]Method{
+		"next":   CSVNext,
+		"header": CSVHeader,
+		"close":  CSVClose,
+	}
+
+	// Dates and times returned by "now", "datetime", "parseTime", etc.
+	methods["datetime"] = timeMethods()
+
+	return methods
+}
+
+/**
+ * Add or replace a method of a type. The type is a type name (e.g. "string" or "int[]"), "array" or "val".
+ * @param typeName : string - The type of the values the method applies to.
+ * @param name : string - The name of the method.
+ * @param method : Method - The method.
+ */
+func (interpreter *Interpreter) RegisterMethod(typeName string, name string, method Method) {
+	if (*interpreter).Methods == nil {
+		(*interpreter).Methods = map[string]map[string]Method{}
+	}
+	if (*interpreter).Methods[typeName] == nil {
+		(*interpreter).Methods[typeName] = map[string]Method{}
+	}
+	(*interpreter).Methods[typeName][name] = method
+}
+
+/**
+ * Find the method of a value. The most specific type is searched first, then "array" and "val".
+ * @param variable : Variable - The value.
+ * @param name : string - The name of the method.
+ * @return Method - The method.
+ * @return bool - True if the method exists.
+ */
+func (interpreter *Interpreter) FindMethod(variable Variable, name string) (Method, bool) {
+	for _, typeName := range methodTypes(variable) {
+		if method, exists := (*interpreter).Methods[typeName][name]; exists {
+			return method, true
+		}
+	}
+	return nil, false
+}
+
+/**
+ * Get the names of the methods callable on a value.
+ * @param variable : Variable - The value.
+ * @return []string - The names of the methods.
+ */
+func (interpreter *Interpreter) MethodNames(variable Variable) []string {
+	names := []string{}
+	for _, typeName := range methodTypes(variable) {
+		for name := range (*interpreter).Methods[typeName] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+/**
+ * Get the types whose methods are callable on a value, from the most specific one.
+ * @param variable : Variable - The value.
+ * @return []string - The type names (e.g. "int[]", "array" and "val").
+ */
+func methodTypes(variable Variable) []string {
+	typeNames := []string{variable.Type}
+	if isArrayType(variable.Type) {
+		typeNames = append(typeNames, "array")
+	}
+	return append(typeNames, "val")
+}
@@ -3,6 +3,8 @@ package kode
 import (
 	"math"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 /**
@@ -96,7 +98,7 @@ func ApplyOperator(op string, val1 Variable, val2 Variable, startLine int) (Vari
 	case "<=":
 		return val1.LessEqual(&val2, startLine)
 	default:
-		return Variable{}, CreateError("Error: Invalid operator ("+op+")", startLine)
+		return Variable{}, CreateError(ErrorMessage(MSG_INVALID_OPERATOR, op), startLine)
 	}
 }
 
@@ -127,7 +129,7 @@ func (val1 *Variable) Add(val2 *Variable, startLine int) (Variable, *ErrorStack)
 		if (*val2).Type == "string" {
 			return Variable{Type: "string", Value: (*val1).Value.(string) + (*val2).Value.(string)}, nil
 		} else if isArrayType((*val2).Type) {
-			return CreateVariable(append([]Variable{(*val1)}, val2.Value.([]Variable)...)), nil
+			return CreateVariable(append([]Variable{(*val1)}, val2.Elements()...)), nil
 		} else {
 			break
 		}
@@ -144,14 +146,17 @@ func (val1 *Variable) Add(val2 *Variable, startLine int) (Variable, *ErrorStack)
 
 		// Array type
 		if isArrayType((*val1).Type) && isArrayType((*val2).Type) && (*val1).Type == (*val2).Type {
-			return CreateVariable(append(val1.Value.([]Variable), val2.Value.([]Variable)...)), nil
+			// Always allocate new storage so the result never shares elements with the operands
+			array := make([]Variable, 0, len(val1.Elements())+len(val2.Elements()))
+			array = append(array, val1.Elements()...)
+			return CreateVariable(append(array, val2.Elements()...)), nil
 		}
 
 		break
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Cannot add values of type ("+(*val1).Type+") and ("+(*val2).Type+")", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_CANNOT_ADD, (*val1).Type, (*val2).Type), startLine)
 }
 
 func (val1 *Variable) Sub(val2 *Variable, startLine int) (Variable, *ErrorStack) {
@@ -185,7 +190,7 @@ func (val1 *Variable) Sub(val2 *Variable, startLine int) (Variable, *ErrorStack)
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Invalid type ("+(*val1).Type+" - "+(*val2).Type+") operation with subtraction", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_INVALID_OPERATION, (*val1).Type, "-", (*val2).Type, "subtraction"), startLine)
 }
 
 func (val1 *Variable) Mult(val2 *Variable, startLine int) (Variable, *ErrorStack) {
@@ -233,7 +238,7 @@ func (val1 *Variable) Mult(val2 *Variable, startLine int) (Variable, *ErrorStack
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Invalid type ("+(*val1).Type+" * "+(*val2).Type+") operation with multiplication", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_INVALID_OPERATION, (*val1).Type, "*", (*val2).Type, "multiplication"), startLine)
 }
 
 func (val1 *Variable) Div(val2 *Variable, startLine int) (Variable, *ErrorStack) {
@@ -243,14 +248,14 @@ func (val1 *Variable) Div(val2 *Variable, startLine int) (Variable, *ErrorStack)
 		if (*val2).Type == "int" {
 
 			if (*val2).Value.(int64) == 0 {
-				return Variable{}, CreateError("Error: Cannot divide by zero", startLine)
+				return Variable{}, CreateError(ErrorMessage(MSG_DIVIDE_BY_ZERO), startLine)
 			}
 
 			return Variable{Type: "int", Value: (*val1).Value.(int64) / (*val2).Value.(int64)}, nil
 		} else if (*val2).Type == "float" {
 
 			if (*val2).Value.(float64) == 0 {
-				return Variable{}, CreateError("Error: Cannot divide by zero", startLine)
+				return Variable{}, CreateError(ErrorMessage(MSG_DIVIDE_BY_ZERO), startLine)
 			}
 
 			return Variable{Type: "float", Value: float64((*val1).Value.(int64)) / (*val2).Value.(float64)}, nil
@@ -263,14 +268,14 @@ func (val1 *Variable) Div(val2 *Variable, startLine int) (Variable, *ErrorStack)
 		if (*val2).Type == "int" {
 
 			if (*val2).Value.(int64) == 0 {
-				return Variable{}, CreateError("Error: Cannot divide by zero", startLine)
+				return Variable{}, CreateError(ErrorMessage(MSG_DIVIDE_BY_ZERO), startLine)
 			}
 
 			return Variable{Type: "float", Value: (*val1).Value.(float64) / float64((*val2).Value.(int64))}, nil
 		} else if (*val2).Type == "float" {
 
 			if (*val2).Value.(float64) == 0 {
-				return Variable{}, CreateError("Error: Cannot divide by zero", startLine)
+				return Variable{}, CreateError(ErrorMessage(MSG_DIVIDE_BY_ZERO), startLine)
 			}
 
 			return Variable{Type: "float", Value: (*val1).Value.(float64) / (*val2).Value.(float64)}, nil
@@ -283,7 +288,7 @@ func (val1 *Variable) Div(val2 *Variable, startLine int) (Variable, *ErrorStack)
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Invalid type ("+(*val1).Type+" / "+(*val2).Type+") operation with division", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_INVALID_OPERATION, (*val1).Type, "/", (*val2).Type, "division"), startLine)
 }
 
 func (val1 *Variable) Pow(val2 *Variable, startLine int) (Variable, *ErrorStack) {
@@ -313,7 +318,7 @@ func (val1 *Variable) Pow(val2 *Variable, startLine int) (Variable, *ErrorStack)
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Invalid type ("+(*val1).Type+" ^ "+(*val2).Type+") operation with exponent", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_INVALID_OPERATION, (*val1).Type, "^", (*val2).Type, "exponent"), startLine)
 }
 
 func (val1 *Variable) Mod(val2 *Variable, startLine int) (Variable, *ErrorStack) {
@@ -323,14 +328,14 @@ func (val1 *Variable) Mod(val2 *Variable, startLine int) (Variable, *ErrorStack)
 		if (*val2).Type == "int" {
 
 			if (*val2).Value.(int64) == 0 {
-				return Variable{}, CreateError("Error: Cannot divide by zero", startLine)
+				return Variable{}, CreateError(ErrorMessage(MSG_DIVIDE_BY_ZERO), startLine)
 			}
 
 			return Variable{Type: "int", Value: (*val1).Value.(int64) % (*val2).Value.(int64)}, nil
 		} else if (*val2).Type == "float" {
 
 			if (*val2).Value.(float64) == 0 {
-				return Variable{}, CreateError("Error: Cannot divide by zero", startLine)
+				return Variable{}, CreateError(ErrorMessage(MSG_DIVIDE_BY_ZERO), startLine)
 			}
 
 			k := math.Floor(float64((*val1).Value.(int64)) / (*val2).Value.(float64))
@@ -345,7 +350,7 @@ func (val1 *Variable) Mod(val2 *Variable, startLine int) (Variable, *ErrorStack)
 		if (*val2).Type == "int" {
 
 			if (*val2).Value.(int64) == 0 {
-				return Variable{}, CreateError("Error: Modulo by zero", startLine)
+				return Variable{}, CreateError(ErrorMessage(MSG_MODULO_BY_ZERO), startLine)
 			}
 
 			k := math.Floor((*val1).Value.(float64) / float64((*val2).Value.(int64)))
@@ -354,7 +359,7 @@ func (val1 *Variable) Mod(val2 *Variable, startLine int) (Variable, *ErrorStack)
 		} else if (*val2).Type == "float" {
 
 			if (*val2).Value.(float64) == 0 {
-				return Variable{}, CreateError("Error: Modulo by zero", startLine)
+				return Variable{}, CreateError(ErrorMessage(MSG_MODULO_BY_ZERO), startLine)
 			}
 
 			k := math.Floor((*val1).Value.(float64) / val2.Value.(float64))
@@ -369,7 +374,7 @@ func (val1 *Variable) Mod(val2 *Variable, startLine int) (Variable, *ErrorStack)
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Invalid type ("+(*val1).Type+" % "+(*val2).Type+") operation with modulo", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_INVALID_OPERATION, (*val1).Type, "%", (*val2).Type, "modulo"), startLine)
 }
 
 func (val1 *Variable) Neg(startLine int) (Variable, *ErrorStack) {
@@ -392,7 +397,7 @@ func (val1 *Variable) Neg(startLine int) (Variable, *ErrorStack) {
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Invalid type ("+(*val1).Type+") operation with negation", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_INVALID_NEGATION, (*val1).Type), startLine)
 }
 
 func (val1 *Variable) Equal(val2 *Variable, startLine int) (Variable, *ErrorStack) {
@@ -435,12 +440,19 @@ func (val1 *Variable) Equal(val2 *Variable, startLine int) (Variable, *ErrorStac
 			break
 		}
 
+	case "datetime":
+		if (*val2).Type == "datetime" {
+			return Variable{Type: "bool", Value: (*val1).Value.(time.Time).Equal((*val2).Value.(time.Time))}, nil
+		} else {
+			break
+		}
+
 	default:
 		break
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Cannot compare "+(*val1).Type+" with "+(*val2).Type+" type", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_CANNOT_COMPARE, (*val1).Type, (*val2).Type), startLine)
 }
 
 func (val1 *Variable) NotEqual(val2 *Variable, startLine int) (Variable, *ErrorStack) {
@@ -474,7 +486,14 @@ func (val1 *Variable) Greater(val2 *Variable, startLine int) (Variable, *ErrorSt
 
 	case "string":
 		if (*val2).Type == "string" {
-			return Variable{Type: "bool", Value: len((*val1).Value.(string)) > len((*val2).Value.(string))}, nil
+			return Variable{Type: "bool", Value: utf8.RuneCountInString((*val1).Value.(string)) > utf8.RuneCountInString((*val2).Value.(string))}, nil
+		} else {
+			break
+		}
+
+	case "datetime":
+		if (*val2).Type == "datetime" {
+			return Variable{Type: "bool", Value: (*val1).Value.(time.Time).After((*val2).Value.(time.Time))}, nil
 		} else {
 			break
 		}
@@ -484,7 +503,7 @@ func (val1 *Variable) Greater(val2 *Variable, startLine int) (Variable, *ErrorSt
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Cannot compare "+(*val1).Type+" with "+(*val2).Type+" type", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_CANNOT_COMPARE, (*val1).Type, (*val2).Type), startLine)
 }
 
 func (val1 *Variable) Less(val2 *Variable, startLine int) (Variable, *ErrorStack) {
@@ -509,7 +528,14 @@ func (val1 *Variable) Less(val2 *Variable, startLine int) (Variable, *ErrorStack
 
 	case "string":
 		if (*val2).Type == "string" {
-			return Variable{Type: "bool", Value: len((*val1).Value.(string)) < len((*val2).Value.(string))}, nil
+			return Variable{Type: "bool", Value: utf8.RuneCountInString((*val1).Value.(string)) < utf8.RuneCountInString((*val2).Value.(string))}, nil
+		} else {
+			break
+		}
+
+	case "datetime":
+		if (*val2).Type == "datetime" {
+			return Variable{Type: "bool", Value: (*val1).Value.(time.Time).Before((*val2).Value.(time.Time))}, nil
 		} else {
 			break
 		}
@@ -519,7 +545,7 @@ func (val1 *Variable) Less(val2 *Variable, startLine int) (Variable, *ErrorStack
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Cannot compare "+(*val1).Type+" with "+(*val2).Type+" type", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_CANNOT_COMPARE, (*val1).Type, (*val2).Type), startLine)
 }
 
 func (val1 *Variable) GreaterEqual(val2 *Variable, startLine int) (Variable, *ErrorStack) {
@@ -544,7 +570,14 @@ func (val1 *Variable) GreaterEqual(val2 *Variable, startLine int) (Variable, *Er
 
 	case "string":
 		if (*val2).Type == "string" {
-			return Variable{Type: "bool", Value: len((*val1).Value.(string)) >= len((*val2).Value.(string))}, nil
+			return Variable{Type: "bool", Value: utf8.RuneCountInString((*val1).Value.(string)) >= utf8.RuneCountInString((*val2).Value.(string))}, nil
+		} else {
+			break
+		}
+
+	case "datetime":
+		if (*val2).Type == "datetime" {
+			return Variable{Type: "bool", Value: !(*val1).Value.(time.Time).Before((*val2).Value.(time.Time))}, nil
 		} else {
 			break
 		}
@@ -554,7 +587,7 @@ func (val1 *Variable) GreaterEqual(val2 *Variable, startLine int) (Variable, *Er
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Cannot compare "+(*val1).Type+" with "+(*val2).Type+" type", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_CANNOT_COMPARE, (*val1).Type, (*val2).Type), startLine)
 }
 
 func (val1 *Variable) LessEqual(val2 *Variable, startLine int) (Variable, *ErrorStack) {
@@ -579,7 +612,14 @@ func (val1 *Variable) LessEqual(val2 *Variable, startLine int) (Variable, *Error
 
 	case "string":
 		if (*val2).Type == "string" {
-			return Variable{Type: "bool", Value: len((*val1).Value.(string)) <= len((*val2).Value.(string))}, nil
+			return Variable{Type: "bool", Value: utf8.RuneCountInString((*val1).Value.(string)) <= utf8.RuneCountInString((*val2).Value.(string))}, nil
+		} else {
+			break
+		}
+
+	case "datetime":
+		if (*val2).Type == "datetime" {
+			return Variable{Type: "bool", Value: !(*val1).Value.(time.Time).After((*val2).Value.(time.Time))}, nil
 		} else {
 			break
 		}
@@ -589,5 +629,5 @@ func (val1 *Variable) LessEqual(val2 *Variable, startLine int) (Variable, *Error
 	}
 
 	// If incompatible types, return error
-	return Variable{}, CreateError("Error: Cannot compare "+(*val1).Type+" with "+(*val2).Type+" type", startLine)
+	return Variable{}, CreateError(ErrorMessage(MSG_CANNOT_COMPARE, (*val1).Type, (*val2).Type), startLine)
 }
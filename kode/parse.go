@@ -21,12 +21,28 @@ func LineParse(txt string) []string {
  * @return []string - Resulting tokens.
  */
 func InlineParse(txt string, delimiters []string, includeDelimiter bool) []string {
+	tokens, _ := InlineParseSpans(txt, 0, delimiters, includeDelimiter)
+	return tokens
+}
+
+/**
+ * Parse the individual tokens of a piece of a line of code, with where each token is in the line.
+ * @param txt : string - The code to parse.
+ * @param offset : int - The byte offset of the code in its line.
+ * @param delimiters : []string - Set of delimiters to use. Order defines precedence.
+ * @param includeDelimiter : bool - True if the delimiter should be included in the token.
+ * @return []string - Resulting tokens.
+ * @return []Span - The span of each token in the line.
+ */
+func InlineParseSpans(txt string, offset int, delimiters []string, includeDelimiter bool) ([]string, []Span) {
 	tokens := []string{}
+	spans := []Span{}
 
 	tempToken := ""
+	tempStart := 0
 
 	for i := 0; i < len(txt); i++ {
-		char := string(txt[i])
+		char := txt[i : i+1] // Keep the raw byte so multi-byte characters are not split
 		isDelimiter := false
 
 		// Check if the current character is forming a delimiter
@@ -39,6 +55,7 @@ func InlineParse(txt string, delimiters []string, includeDelimiter bool) []strin
 				// Dump the current token if it is not empty
 				if tempToken != "" {
 					tokens = append(tokens, tempToken)
+					spans = append(spans, Span{offset + tempStart, offset + i})
 					// Reset the current token
 					tempToken = ""
 				}
@@ -46,6 +63,7 @@ func InlineParse(txt string, delimiters []string, includeDelimiter bool) []strin
 				// Check if the delimiter should be included
 				if includeDelimiter {
 					tokens = append(tokens, delimiter)
+					spans = append(spans, Span{offset + i, offset + i + delimiterSize})
 				}
 
 				// Skip the delimiter characters
@@ -57,6 +75,9 @@ func InlineParse(txt string, delimiters []string, includeDelimiter bool) []strin
 
 		// Did not find a delimiter, add the character to the token
 		if !isDelimiter {
+			if tempToken == "" {
+				tempStart = i
+			}
 			tempToken += char
 		}
 
@@ -65,12 +86,14 @@ func InlineParse(txt string, delimiters []string, includeDelimiter bool) []strin
 	// Dump the last token if it is not empty
 	if tempToken != "" {
 		tokens = append(tokens, tempToken)
+		spans = append(spans, Span{offset + tempStart, offset + len(txt)})
 	}
 
 	// Remove certain words for strings
 	result := []string{}
+	resultSpans := []Span{}
 	removeEmpty := true
-	for _, word := range tokens {
+	for i, word := range tokens {
 
 		if word == "\"" {
 			removeEmpty = !removeEmpty
@@ -78,10 +101,11 @@ func InlineParse(txt string, delimiters []string, includeDelimiter bool) []strin
 
 		if !removeEmpty || (word != " " && word != "\t") {
 			result = append(result, word)
+			resultSpans = append(resultSpans, spans[i])
 		}
 	}
 
-	return result
+	return result, resultSpans
 }
 
 /**
@@ -93,3 +117,99 @@ func HandleEscapeCharacters(txt string) string {
 	txt = strings.Replace(txt, "\\\"", "\"", -1) // Replace escaped quotes
 	return strings.Replace(txt, "\\n", "\n", -1) // Replace escaped newlines
 }
+
+// Keywords starting a statement rather than an expression
+var STATEMENT_KEYWORDS = []string{"val", "int", "float", "string", "bool", "const", "if", "else", "end", "func", "return", "for", "break", "try", "catch"}
+
+/**
+ * Check if a line of code is empty or only contains a comment.
+ * @param line : string - The line of code.
+ * @return bool - True if the line has no code.
+ */
+func IsBlankLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+/**
+ * Get the significant tokens of a line of code: spaces and comments are removed and each string literal
+ * (quotes included) is a single token.
+ * @param line : string - The line of code.
+ * @return []string - The tokens.
+ */
+func LineTokens(line string) []string {
+	tokens, _ := LineSpans(line)
+	return tokens
+}
+
+/**
+ * Get the significant tokens of a line of code (see LineTokens) with where each of them is in the line.
+ * @param line : string - The line of code.
+ * @return []string - The tokens.
+ * @return []Span - The span of each token.
+ */
+func LineSpans(line string) ([]string, []Span) {
+	tokens := []string{}
+	spans := []Span{}
+	str := ""
+	start := 0
+	isInString := false
+	parsed, parsedSpans := InlineParseSpans(line, 0, []string{" ", "\t", "\r", "[", "]", "(", ")", ",", ".", "==", "!=", ">=", "<=", ":=", "=", "#", "\\\"", "\""}, true)
+	for i, token := range parsed {
+		if isInString {
+			str += token
+			if token == "\"" {
+				tokens = append(tokens, str)
+				spans = append(spans, Span{start, parsedSpans[i].End})
+				isInString = false
+			}
+			continue
+		}
+
+		switch {
+		case token == "\"":
+			str = token
+			start = parsedSpans[i].Start
+			isInString = true
+		case token == "#":
+			return tokens, spans
+		case strings.TrimSpace(token) != "":
+			tokens = append(tokens, token)
+			spans = append(spans, parsedSpans[i])
+		}
+	}
+
+	// Unclosed string
+	if isInString {
+		tokens = append(tokens, str)
+		spans = append(spans, Span{start, len(line)})
+	}
+	return tokens, spans
+}
+
+/**
+ * Check if a line of code is an expression (e.g. "1 + 2" or "sqrt(x)") and not a statement (e.g. a declaration,
+ * an assignment or a block).
+ * @param line : string - The line of code.
+ * @return bool - True if the line is an expression.
+ */
+func IsExpressionLine(line string) bool {
+	tokens := LineTokens(line)
+	if len(tokens) == 0 {
+		return false
+	}
+
+	for _, keyword := range STATEMENT_KEYWORDS {
+		if tokens[0] == keyword {
+			return false
+		}
+	}
+
+	// Assignments are statements
+	for _, token := range tokens {
+		if token == "=" || token == ":=" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,215 @@
+package kode
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+/**
+ * Get the random number generator of the interpreter running the scope.
+ * @return *rand.Rand - The random number generator.
+ */
+func (scope *Function) Rand() *rand.Rand {
+	return scope.GetInterpreter().Random
+}
+
+/**
+ * Get a random float between 0 (included) and 1 (excluded).
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Random(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) > 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "0", "random"), startLine)
+	}
+
+	variable := CreateVariable(scope.Rand().Float64())
+	return &variable, nil
+}
+
+/**
+ * Seed the random number generator so the following random values are reproducible.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - Null.
+ * @return error - The error if one occurs.
+**/
+func Seed(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "seed"), startLine)
+	}
+
+	if args[0].Type != "int" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "an int", "seed"), startLine)
+	}
+
+	scope.GetInterpreter().Seed(args[0].Value.(int64))
+	return NullVariable(), nil
+}
+
+/**
+ * Get a random int between two bounds (both included).
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func RandInt(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", "randInt"), startLine)
+	}
+
+	if args[0].Type != "int" || args[1].Type != "int" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENTS_MUST, "ints", "randInt"), startLine)
+	}
+
+	low, high := args[0].Value.(int64), args[1].Value.(int64)
+	if low > high {
+		return NullVariable(), CreateError(ErrorMessage(MSG_BOUND_VALUES, strconv.FormatInt(low, 10), strconv.FormatInt(high, 10), "randInt"), startLine)
+	}
+
+	// The range overflows when the bounds cover almost every int
+	size := uint64(high-low) + 1
+	if size == 0 || size > uint64(1<<63-1) {
+		return NullVariable(), CreateError(ErrorMessage(MSG_RANGE_TOO_LARGE, "randInt"), startLine)
+	}
+
+	variable := CreateVariable(low + scope.Rand().Int63n(int64(size)))
+	return &variable, nil
+}
+
+/**
+ * Get a random element of an array.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Choice(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "choice"), startLine)
+	}
+
+	if !isArrayType(args[0].Type) {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "an array", "choice"), startLine)
+	}
+
+	elements := args[0].Elements()
+	if len(elements) == 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_CHOOSE_EMPTY), startLine)
+	}
+
+	element := elements[scope.Rand().Intn(len(elements))]
+	return &element, nil
+}
+
+/**
+ * Shuffle the elements of an array in place.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - Null.
+ * @return error - The error if one occurs.
+**/
+func Shuffle(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "shuffle"), startLine)
+	}
+
+	err := checkMutableArray(args[0], "shuffle", startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	elements := args[0].Elements()
+	scope.Rand().Shuffle(len(elements), func(i int, j int) {
+		elements[i], elements[j] = elements[j], elements[i]
+	})
+
+	return NullVariable(), nil
+}
+
+/**
+ * Get k distinct random elements of an array in a new array.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Sample(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", "sample"), startLine)
+	}
+
+	if !isArrayType(args[0].Type) {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "an array", "sample"), startLine)
+	}
+
+	if args[1].Type != "int" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "an int", "sample"), startLine)
+	}
+
+	elements := args[0].Elements()
+	k := args[1].Value.(int64)
+	if k < 0 || k > int64(len(elements)) {
+		return NullVariable(), CreateError(ErrorMessage(MSG_SAMPLE_SIZE, strconv.FormatInt(k, 10), strconv.Itoa(len(elements))), startLine)
+	}
+
+	array := make([]Variable, k)
+	for i, index := range scope.Rand().Perm(len(elements))[:k] {
+		array[i] = elements[index]
+	}
+
+	variable := Variable{Value: &Array{Elements: array}, Type: args[0].Type}
+	return &variable, nil
+}
+
+/**
+ * Get a random float from a normal (Gaussian) distribution.
+ * @param args :[]*Variable - The mean and the standard deviation (0 and 1 by default).
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func RandNormal(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 0 && len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "0 or 2", "randNormal"), startLine)
+	}
+
+	mean, stdDev := 0.0, 1.0
+	if len(args) == 2 {
+		var okMean, okStdDev bool
+		mean, okMean = toNumber(args[0])
+		stdDev, okStdDev = toNumber(args[1])
+		if !okMean || !okStdDev {
+			return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENTS_MUST, "floats or ints", "randNormal"), startLine)
+		}
+		if stdDev < 0 {
+			return NullVariable(), CreateError(ErrorMessage(MSG_POSITIVE_DEVIATION, "randNormal"), startLine)
+		}
+	}
+
+	variable := CreateVariable(scope.Rand().NormFloat64()*stdDev + mean)
+	return &variable, nil
+}
+
+/**
+ * Get a random float from an exponential distribution.
+ * @param args :[]*Variable - The rate of the distribution (1 by default).
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func RandExp(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) > 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "0 or 1", "randExp"), startLine)
+	}
+
+	rate := 1.0
+	if len(args) == 1 {
+		var ok bool
+		rate, ok = toNumber(args[0])
+		if !ok {
+			return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a float or an int", "randExp"), startLine)
+		}
+		if rate <= 0 {
+			return NullVariable(), CreateError(ErrorMessage(MSG_POSITIVE_RATE, "randExp"), startLine)
+		}
+	}
+
+	variable := CreateVariable(scope.Rand().ExpFloat64() / rate)
+	return &variable, nil
+}
@@ -0,0 +1,258 @@
+package kode
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+/**
+ * Compile a regular expression.
+ * @param args :[]*Variable - The pattern.
+ * @return *Variable - The compiled regular expression.
+ * @return error - The error if one occurs, with the invalid part of the pattern and its position.
+**/
+func Regex(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "regex"), startLine)
+	}
+
+	if args[0].Type != "string" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string", "regex"), startLine)
+	}
+
+	pattern := args[0].Value.(string)
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		reason := err.Error()
+		position := -1
+		if syntaxError, ok := err.(*syntax.Error); ok {
+			reason = string(syntaxError.Code)
+			// Show the invalid part of the pattern when it is not the whole pattern
+			if syntaxError.Expr != "" && syntaxError.Expr != pattern {
+				reason += " " + QuoteString(syntaxError.Expr)
+			}
+			position = regexErrorPosition(pattern, syntaxError)
+		}
+		if position >= 0 {
+			return NullVariable(), CreateError(ErrorMessage(MSG_INVALID_REGEX_AT, QuoteString(pattern), strconv.Itoa(position), reason), startLine)
+		}
+		return NullVariable(), CreateError(ErrorMessage(MSG_INVALID_REGEX, QuoteString(pattern), reason), startLine)
+	}
+
+	variable := CreateVariable(compiled)
+	return &variable, nil
+}
+
+/**
+ * Find the position of the invalid part of a pattern: the unbalanced parenthesis, or the first place where the invalid
+ * expression starts. A match cannot start in the middle of an escape sequence (e.g. the invalid escape of the pattern
+ * \\q\q is the second one).
+ * @param pattern : string - The pattern.
+ * @param syntaxError : *syntax.Error - The error of the pattern.
+ * @return int - The position in characters, starting at 0, or -1 if it is unknown.
+**/
+func regexErrorPosition(pattern string, syntaxError *syntax.Error) int {
+	parentheses := []int{} // The positions of the open parentheses
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		start := i
+
+		switch {
+		case pattern[i] == '\\':
+			i++ // The escaped character
+		case inClass && strings.HasPrefix(pattern[i:], "[:") && strings.Contains(pattern[i:], ":]"):
+			i += strings.Index(pattern[i:], ":]") + 1 // A named class (e.g. "[:alpha:]")
+		case inClass:
+			inClass = pattern[i] != ']'
+		case pattern[i] == '[':
+			inClass = true
+			// A "]" right after the opening bracket is a character of the class (e.g. "[]a]" or "[^]a]")
+			if strings.HasPrefix(pattern[i+1:], "^") {
+				i++
+			}
+			if strings.HasPrefix(pattern[i+1:], "]") {
+				i++
+			}
+		case pattern[i] == '(':
+			parentheses = append(parentheses, start)
+		case pattern[i] == ')':
+			if len(parentheses) == 0 && syntaxError.Code == syntax.ErrUnexpectedParen {
+				return utf8.RuneCountInString(pattern[:start])
+			}
+			if len(parentheses) > 0 {
+				parentheses = parentheses[:len(parentheses)-1]
+			}
+		}
+
+		if syntaxError.Code != syntax.ErrMissingParen && syntaxError.Code != syntax.ErrUnexpectedParen && strings.HasPrefix(pattern[start:], syntaxError.Expr) {
+			return utf8.RuneCountInString(pattern[:start])
+		}
+	}
+
+	if syntaxError.Code == syntax.ErrMissingParen && len(parentheses) > 0 {
+		return utf8.RuneCountInString(pattern[:parentheses[len(parentheses)-1]])
+	}
+	return -1
+}
+
+/**
+ * Check the arguments of a regular expression method.
+ * @param name : string - The name of the method.
+ * @param args :[]*Variable - The regular expression followed by the arguments of the method.
+ * @param count : int - The expected number of arguments (including the regular expression).
+ * @return *regexp.Regexp - The regular expression.
+ * @return error - The error if one occurs.
+**/
+func checkRegexArgs(name string, args []*Variable, count int, startLine int) (*regexp.Regexp, *ErrorStack) {
+	if len(args) != count {
+		if count == 2 {
+			return nil, CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", name), startLine)
+		}
+		return nil, CreateError(ErrorMessage(MSG_EXPECTED_ARGS, strconv.Itoa(count-1), name), startLine)
+	}
+
+	if args[0].Type != "regex" {
+		return nil, CreateError(ErrorMessage(MSG_CALLED_ON, name, "a regular expression"), startLine)
+	}
+
+	for i, arg := range args[1:] {
+		if arg.Type != "string" {
+			return nil, CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, strconv.Itoa(i+1), "a string", name), startLine)
+		}
+	}
+
+	return args[0].Value.(*regexp.Regexp), nil
+}
+
+/**
+ * Check if a regular expression matches a string.
+ * e.g. regex("[0-9]+").test("abc123")
+ * @param args :[]*Variable - The regular expression and the string.
+ * @return *Variable - True if the string matches.
+ * @return error - The error if one occurs.
+**/
+func RegexTest(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	compiled, err := checkRegexArgs("test", args, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	variable := CreateVariable(compiled.MatchString(args[1].Value.(string)))
+	return &variable, nil
+}
+
+/**
+ * Get the first match of a regular expression in a string, or null if there is none.
+ * @param args :[]*Variable - The regular expression and the string.
+ * @return *Variable - The first match, or null.
+ * @return error - The error if one occurs.
+**/
+func RegexFind(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	compiled, err := checkRegexArgs("find", args, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	match := compiled.FindStringIndex(args[1].Value.(string))
+	if match == nil {
+		return NullVariable(), nil
+	}
+
+	variable := CreateVariable(args[1].Value.(string)[match[0]:match[1]])
+	return &variable, nil
+}
+
+/**
+ * Get every match of a regular expression in a string.
+ * @param args :[]*Variable - The regular expression and the string.
+ * @return *Variable - The matches.
+ * @return error - The error if one occurs.
+**/
+func RegexFindAll(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	compiled, err := checkRegexArgs("findAll", args, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	return createStringArray(compiled.FindAllString(args[1].Value.(string), -1)), nil
+}
+
+/**
+ * Get the capture groups of the first match of a regular expression, or null if there is no match.
+ * Named groups are returned as an object (e.g. match.year), otherwise the groups are returned as an array.
+ * Groups that did not participate in the match are null.
+ * @param args :[]*Variable - The regular expression and the string.
+ * @return *Variable - The groups, or null.
+ * @return error - The error if one occurs.
+**/
+func RegexGroups(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	compiled, err := checkRegexArgs("groups", args, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	str := args[1].Value.(string)
+	match := compiled.FindStringSubmatchIndex(str)
+	if match == nil {
+		return NullVariable(), nil
+	}
+
+	// Get the value of each group, skipping the whole match
+	groups := []Variable{}
+	for i := 1; i < len(match)/2; i++ {
+		if match[2*i] < 0 {
+			groups = append(groups, *NullVariable())
+		} else {
+			groups = append(groups, CreateVariable(str[match[2*i]:match[2*i+1]]))
+		}
+	}
+
+	// Named groups
+	fields := map[string]*Variable{}
+	for i, name := range compiled.SubexpNames() {
+		if name != "" {
+			fields[name] = &groups[i-1]
+		}
+	}
+	if len(fields) > 0 {
+		variable := CreateObject("groups", fields)
+		return &variable, nil
+	}
+
+	variable := CreateVariable(groups)
+	return &variable, nil
+}
+
+/**
+ * Replace every match of a regular expression. The replacement can refer to groups with $1 or ${name}.
+ * @param args :[]*Variable - The regular expression, the string and the replacement.
+ * @return *Variable - The string with the matches replaced.
+ * @return error - The error if one occurs.
+**/
+func RegexReplace(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	compiled, err := checkRegexArgs("replace", args, 3, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	variable := CreateVariable(compiled.ReplaceAllString(args[1].Value.(string), args[2].Value.(string)))
+	return &variable, nil
+}
+
+/**
+ * Split a string around the matches of a regular expression.
+ * @param args :[]*Variable - The regular expression and the string.
+ * @return *Variable - The parts of the string.
+ * @return error - The error if one occurs.
+**/
+func RegexSplit(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	compiled, err := checkRegexArgs("split", args, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	return createStringArray(compiled.Split(args[1].Value.(string), -1)), nil
+}
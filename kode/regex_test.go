@@ -0,0 +1,26 @@
+package kode
+
+import "testing"
+
+func TestRegexErrorPositions(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected string
+	}{
+		{pattern: `a(b`, expected: `Invalid regular expression "a(b" at position 1: missing closing )`},
+		{pattern: `a(b(c)`, expected: `Invalid regular expression "a(b(c)" at position 1: missing closing )`},
+		{pattern: `[(]a)`, expected: `Invalid regular expression "[(]a)" at position 4: unexpected )`},
+		{pattern: `\\q\q`, expected: `Invalid regular expression "\\q\q" at position 3: invalid escape sequence "\q"`},
+		{pattern: `é**`, expected: `Invalid regular expression "é**" at position 1: invalid nested repetition operator "**"`},
+	}
+
+	for _, test := range tests {
+		err := runError(t, `regex("`+test.pattern+`")`)
+		if message := ErrorDiagnostic(err).Message; message != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.pattern, test.expected, message)
+		}
+		if code := err.ErrorCode(); code != E_INVALID_FORMAT {
+			t.Errorf("%q: expected %s, got %s", test.pattern, E_INVALID_FORMAT, code)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package kode
+
+// ! Session : A main scope kept between runs, so each run sees the variables and functions of the previous ones (e.g. a REPL).
+type Session struct {
+	scope Function
+}
+
+/**
+ * Create a session running code with the interpreter.
+ * @return *Session - The new session.
+ */
+func (interpreter *Interpreter) NewSession() *Session {
+	return &Session{scope: interpreter.createMainScope("")}
+}
+
+/**
+ * Run code in the session and get the value of its last line if it is an expression.
+ * @param code : string - The code to run.
+ * @return *Variable - The value of the last line, or nil if the last line is a statement.
+ * @return error - The error if one occurs (see Interpreter.Run). An *ExitError is also returned if the code exits with code 0.
+ */
+func (session *Session) Eval(code string) (*Variable, error) {
+	return runMainScope(&(*session).scope, code, true)
+}
+
+/**
+ * Check if the code is a complete program in the session, with the keywords chosen by the pragmas of the previous runs.
+ * @param code : string - The code.
+ * @return bool - True if every block is closed.
+ */
+func (session *Session) IsComplete(code string) bool {
+	return isComplete(code, (*session).scope.GetInterpreter().keywords)
+}
+
+/**
+ * Check if the code is a complete program or if some blocks (e.g. "if" without "end if") are still open.
+ * @param code : string - The code.
+ * @return bool - True if every block is closed.
+ */
+func IsComplete(code string) bool {
+	return isComplete(code, "")
+}
+
+/**
+ * Check if the code is a complete program (see IsComplete).
+ * @param code : string - The code.
+ * @param keywords : string - The language of the keywords if the code has no pragma (see KEYWORD_ALIASES).
+ * @return bool - True if every block is closed.
+ */
+func isComplete(code string, keywords string) bool {
+	depth := 0
+	aliases := codeKeywords(code, keywords)
+	for _, line := range LineParse(code) {
+		tokens := LineTokens(translateKeywords(line, aliases))
+		if len(tokens) == 0 {
+			continue
+		}
+		switch tokens[0] {
+		case "if", "for", "func", "try":
+			depth++
+		case "end":
+			depth--
+		}
+	}
+	return depth <= 0
+}
@@ -0,0 +1,452 @@
+package kode
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/**
+ * Check the number of arguments of a string function and that the first ones are strings.
+ * @param name : string - The name of the Kode function.
+ * @param args :[]*Variable - The arguments to the function.
+ * @param count : int - The expected number of arguments.
+ * @param stringCount : int - The number of leading arguments that must be strings.
+ * @return error - The error if one occurs.
+**/
+func checkStringArgs(name string, args []*Variable, count int, stringCount int, startLine int) *ErrorStack {
+	if len(args) != count {
+		if count == 1 {
+			return CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", name), startLine)
+		}
+		return CreateError(ErrorMessage(MSG_EXPECTED_ARGS, strconv.Itoa(count), name), startLine)
+	}
+
+	for i := 0; i < stringCount; i++ {
+		if args[i].Type != "string" {
+			return CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, strconv.Itoa(i+1), "a string", name), startLine)
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Create a string array variable.
+ * @param values : []string - The strings.
+ * @return *Variable - The string array.
+ */
+func createStringArray(values []string) *Variable {
+	array := make([]Variable, len(values))
+	for i, value := range values {
+		array[i] = CreateVariable(value)
+	}
+	return &Variable{Value: &Array{Elements: array}, Type: "string[]"}
+}
+
+/**
+ * Split a string around a separator. An empty separator splits every character.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Split(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("split", args, 2, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	return createStringArray(strings.Split(args[0].Value.(string), args[1].Value.(string))), nil
+}
+
+/**
+ * Join the elements of an array with a separator. Elements that are not strings are converted like "toString".
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Join(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2", "join"), startLine)
+	}
+
+	if !isArrayType(args[0].Type) {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "an array", "join"), startLine)
+	}
+
+	if args[1].Type != "string" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "a string", "join"), startLine)
+	}
+
+	values := make([]string, len(args[0].Elements()))
+	for i, element := range args[0].Elements() {
+		values[i] = FormatVariable(element)
+	}
+
+	variable := CreateVariable(strings.Join(values, args[1].Value.(string)))
+	return &variable, nil
+}
+
+/**
+ * Replace the first occurrence of a substring.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Replace(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("replace", args, 3, 3, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	variable := CreateVariable(strings.Replace(args[0].Value.(string), args[1].Value.(string), args[2].Value.(string), 1))
+	return &variable, nil
+}
+
+/**
+ * Replace every occurrence of a substring.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func ReplaceAll(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("replaceAll", args, 3, 3, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	variable := CreateVariable(strings.ReplaceAll(args[0].Value.(string), args[1].Value.(string), args[2].Value.(string)))
+	return &variable, nil
+}
+
+/**
+ * Convert a byte index of a string into a character (rune) index.
+ * @param str : string - The string.
+ * @param index : int - The byte index, or -1.
+ * @return int64 - The character index, or -1.
+ */
+func runeIndex(str string, index int) int64 {
+	if index < 0 {
+		return -1
+	}
+	return int64(utf8.RuneCountInString(str[:index]))
+}
+
+/**
+ * Get the index (in characters) of the first occurrence of a substring, or -1 if it is not found.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func IndexOf(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("indexOf", args, 2, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	str := args[0].Value.(string)
+	variable := CreateVariable(runeIndex(str, strings.Index(str, args[1].Value.(string))))
+	return &variable, nil
+}
+
+/**
+ * Get the index (in characters) of the last occurrence of a substring, or -1 if it is not found.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func LastIndexOf(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("lastIndexOf", args, 2, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	str := args[0].Value.(string)
+	variable := CreateVariable(runeIndex(str, strings.LastIndex(str, args[1].Value.(string))))
+	return &variable, nil
+}
+
+/**
+ * Check if a string contains a substring.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Contains(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("contains", args, 2, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	variable := CreateVariable(strings.Contains(args[0].Value.(string), args[1].Value.(string)))
+	return &variable, nil
+}
+
+/**
+ * Check if a string starts with a prefix.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func StartsWith(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("startsWith", args, 2, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	variable := CreateVariable(strings.HasPrefix(args[0].Value.(string), args[1].Value.(string)))
+	return &variable, nil
+}
+
+/**
+ * Check if a string ends with a suffix.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func EndsWith(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("endsWith", args, 2, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	variable := CreateVariable(strings.HasSuffix(args[0].Value.(string), args[1].Value.(string)))
+	return &variable, nil
+}
+
+/**
+ * Remove characters at the start and/or the end of a string.
+ * By default, whitespace is removed. Otherwise, every character of the optional second argument is removed.
+ * @param name : string - The name of the Kode function.
+ * @param args :[]*Variable - The arguments to the function.
+ * @param left : bool - True to trim the start of the string.
+ * @param right : bool - True to trim the end of the string.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func trimString(name string, args []*Variable, left bool, right bool, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 && len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "1 or 2", name), startLine)
+	}
+
+	err := checkStringArgs(name, args, len(args), len(args), startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	shouldTrim := unicode.IsSpace
+	if len(args) == 2 {
+		cutset := args[1].Value.(string)
+		shouldTrim = func(r rune) bool { return strings.ContainsRune(cutset, r) }
+	}
+
+	str := args[0].Value.(string)
+	if left {
+		str = strings.TrimLeftFunc(str, shouldTrim)
+	}
+	if right {
+		str = strings.TrimRightFunc(str, shouldTrim)
+	}
+
+	variable := CreateVariable(str)
+	return &variable, nil
+}
+
+func Trim(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return trimString("trim", args, true, true, startLine)
+}
+
+func TrimLeft(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return trimString("trimLeft", args, true, false, startLine)
+}
+
+func TrimRight(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return trimString("trimRight", args, false, true, startLine)
+}
+
+/**
+ * Pad a string up to a width (in characters) with a padding string (a space by default).
+ * @param name : string - The name of the Kode function.
+ * @param args :[]*Variable - The arguments to the function.
+ * @param left : bool - True to pad the start of the string, false to pad the end.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func padString(name string, args []*Variable, left bool, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 2 && len(args) != 3 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "2 or 3", name), startLine)
+	}
+
+	if args[0].Type != "string" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "a string", name), startLine)
+	}
+
+	if args[1].Type != "int" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "an int", name), startLine)
+	}
+
+	padding := " "
+	if len(args) == 3 {
+		if args[2].Type != "string" || args[2].Value.(string) == "" {
+			return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "3", "a non-empty string", name), startLine)
+		}
+		padding = args[2].Value.(string)
+	}
+
+	str := args[0].Value.(string)
+	missing := int(args[1].Value.(int64)) - utf8.RuneCountInString(str)
+	if missing <= 0 {
+		variable := CreateVariable(str)
+		return &variable, nil
+	}
+
+	// Repeat the padding and cut it to the exact number of missing characters
+	paddingRunes := []rune(strings.Repeat(padding, missing/utf8.RuneCountInString(padding)+1))[:missing]
+	if left {
+		str = string(paddingRunes) + str
+	} else {
+		str = str + string(paddingRunes)
+	}
+
+	variable := CreateVariable(str)
+	return &variable, nil
+}
+
+func PadLeft(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return padString("padLeft", args, true, startLine)
+}
+
+func PadRight(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	return padString("padRight", args, false, startLine)
+}
+
+/**
+ * Repeat a string n times.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Repeat(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("repeat", args, 2, 1, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	if args[1].Type != "int" || args[1].Value.(int64) < 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "a positive int", "repeat"), startLine)
+	}
+
+	variable := CreateVariable(strings.Repeat(args[0].Value.(string), int(args[1].Value.(int64))))
+	return &variable, nil
+}
+
+/**
+ * Reverse a string (keeping accented characters intact) or an array. A new value is returned.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Reverse(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "reverse"), startLine)
+	}
+
+	if isArrayType(args[0].Type) {
+		elements := args[0].Elements()
+		array := make([]Variable, len(elements))
+		for i, element := range elements {
+			array[len(elements)-1-i] = element
+		}
+		variable := Variable{Value: &Array{Elements: array}, Type: args[0].Type}
+		return &variable, nil
+	}
+
+	if args[0].Type != "string" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "a string or an array", "reverse"), startLine)
+	}
+
+	clusters := SplitGraphemes(args[0].Value.(string))
+	for i, j := 0, len(clusters)-1; i < j; i, j = i+1, j-1 {
+		clusters[i], clusters[j] = clusters[j], clusters[i]
+	}
+
+	variable := CreateVariable(strings.Join(clusters, ""))
+	return &variable, nil
+}
+
+/**
+ * Split a string into lines. Both "\n" and "\r\n" line endings are accepted.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Lines(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("lines", args, 1, 1, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	str := strings.TrimSuffix(args[0].Value.(string), "\n")
+	if str == "" {
+		return createStringArray([]string{}), nil
+	}
+
+	lines := strings.Split(str, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	return createStringArray(lines), nil
+}
+
+/**
+ * Split a string into its characters (runes).
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Chars(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("chars", args, 1, 1, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	chars := []string{}
+	for _, r := range args[0].Value.(string) {
+		chars = append(chars, string(r))
+	}
+
+	return createStringArray(chars), nil
+}
+
+/**
+ * Capitalize the first letter of every word and lowercase the other letters.
+ * @param args :[]*Variable - The arguments to the function.
+ * @return *Variable - The result of the function.
+ * @return error - The error if one occurs.
+**/
+func Title(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	err := checkStringArgs("title", args, 1, 1, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	runes := []rune(args[0].Value.(string))
+	startOfWord := true
+	for i, r := range runes {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || r == '\'' {
+			if startOfWord {
+				runes[i] = unicode.ToTitle(r)
+			} else {
+				runes[i] = unicode.ToLower(r)
+			}
+			startOfWord = false
+		} else {
+			startOfWord = !unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+		}
+	}
+
+	variable := CreateVariable(string(runes))
+	return &variable, nil
+}
@@ -0,0 +1,132 @@
+package kode
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/**
+ * Find the name closest to a misspelled name, e.g. "count" for "cout".
+ * Names are compared by edit distance (a swap of two letters counts as one edit) and must be close enough
+ * for their length: one edit, plus one for every 5 characters, and fewer edits than the length of the name.
+ * @param name : string - The misspelled name.
+ * @param candidates : []string - The known names.
+ * @return string - The closest name, or an empty string if none is close enough.
+ */
+func Suggest(name string, candidates []string) string {
+	limit := 1 + len(name)/5
+	if limit >= len([]rune(name)) {
+		limit = len([]rune(name)) - 1
+	}
+
+	// Sort the candidates so that ties are broken the same way on every run
+	sorted := append([]string{}, candidates...)
+	sort.Strings(sorted)
+
+	best, bestDistance := "", limit+1
+	for _, candidate := range sorted {
+		if candidate == name || candidate == "" {
+			continue
+		}
+		distance := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+/**
+ * Get the hint suggesting the name closest to a misspelled name.
+ * @param name : string - The misspelled name.
+ * @param candidates : []string - The known names.
+ * @return Message - The hint (e.g. Did you mean "count"?), or an empty message if no name is close enough.
+ */
+func didYouMean(name string, candidates []string) Message {
+	if suggestion := Suggest(name, candidates); suggestion != "" {
+		return NewMessage(MSG_DID_YOU_MEAN, suggestion)
+	}
+	return Message{}
+}
+
+/**
+ * Get the names that can be used in a scope: its variables and functions, and the built-in functions.
+ * @return []string - The names.
+ */
+func (scope *Function) KnownNames() []string {
+	names := append([]string{}, BUILT_IN_FUNCTIONS...)
+	for name, variable := range (*scope).Variables {
+		if variable != nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+/**
+ * Create the error of a block without its "end". If a later "end" statement looks like a typo of the expected one
+ * (e.g. "end fi" for "end if"), the error suggests to fix it.
+ * @param kind : string - The kind of the block (e.g. "if").
+ * @param name : string - The name after "end" closing the block (e.g. "if" or the name of a function).
+ * @param lines : []string - The lines of code containing the block.
+ * @param index : int - The index of the first line of the block in the lines.
+ * @param startLine : int - The line of the program of the first of the lines.
+ * @return *ErrorStack - The error, on the first line of the block.
+ */
+func unclosedBlockError(kind string, name string, lines []string, index int, startLine int) *ErrorStack {
+	err := CreateSyntaxError(ErrorMessage(MSG_UNCLOSED_BLOCK, kind, name), index+startLine)
+
+	for i := index + 1; i < len(lines); i++ {
+		tokens := LineTokens(lines[i])
+		if len(tokens) > 1 && tokens[0] == "end" && tokens[1] != name && Suggest(tokens[1], []string{name}) != "" {
+			return err.WithHint(NewMessage(MSG_DID_YOU_MEAN_END, name, tokens[1], strconv.Itoa(i+startLine)))
+		}
+	}
+	return err
+}
+
+/**
+ * Get the edit distance between two strings: the number of characters to insert, delete, replace or swap with
+ * the next one to change a string into the other.
+ * @param a : string - The first string.
+ * @param b : string - The second string.
+ * @return int - The distance.
+ */
+func editDistance(a string, b string) int {
+	first, second := []rune(a), []rune(b)
+
+	// distances[i][j] is the distance between the first i characters of a and the first j characters of b
+	distances := make([][]int, len(first)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(second)+1)
+		distances[i][0] = i
+	}
+	for j := range distances[0] {
+		distances[0][j] = j
+	}
+
+	for i := 1; i <= len(first); i++ {
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+
+			distance := distances[i-1][j] + 1 // Deletion
+			if insertion := distances[i][j-1] + 1; insertion < distance {
+				distance = insertion
+			}
+			if replacement := distances[i-1][j-1] + cost; replacement < distance {
+				distance = replacement
+			}
+			if i > 1 && j > 1 && first[i-1] == second[j-2] && first[i-2] == second[j-1] {
+				if swap := distances[i-2][j-2] + 1; swap < distance {
+					distance = swap
+				}
+			}
+			distances[i][j] = distance
+		}
+	}
+	return distances[len(first)][len(second)]
+}
@@ -0,0 +1,89 @@
+package kode
+
+import (
+	"os"
+	"strconv"
+)
+
+/**
+ * Check that the program is allowed to access the environment variables.
+ * @param name : string - The name of the Kode function.
+ * @return error - The error if the access is denied.
+**/
+func (scope *Function) checkEnvPermission(name string, startLine int) *ErrorStack {
+	if !scope.GetInterpreter().Permissions.Env {
+		return CreateError(ErrorMessage(MSG_DENIED_ENV, name), startLine)
+	}
+	return nil
+}
+
+/**
+ * Get the value of an environment variable, or null if it is not set.
+ * @param args :[]*Variable - The name of the environment variable.
+ * @return *Variable - The value.
+ * @return error - The error if one occurs.
+**/
+func Env(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if err := checkStringArgs("env", args, 1, 1, startLine); err != nil {
+		return NullVariable(), err
+	}
+
+	if err := scope.checkEnvPermission("env", startLine); err != nil {
+		return NullVariable(), err
+	}
+
+	value, exists := os.LookupEnv(args[0].Value.(string))
+	if !exists {
+		return NullVariable(), nil
+	}
+
+	variable := CreateVariable(value)
+	return &variable, nil
+}
+
+/**
+ * Set the value of an environment variable for the program and the processes it starts.
+ * @param args :[]*Variable - The name and the value of the environment variable.
+ * @return *Variable - Null.
+ * @return error - The error if one occurs.
+**/
+func SetEnv(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if err := checkStringArgs("setEnv", args, 2, 2, startLine); err != nil {
+		return NullVariable(), err
+	}
+
+	if err := scope.checkEnvPermission("setEnv", startLine); err != nil {
+		return NullVariable(), err
+	}
+
+	name := args[0].Value.(string)
+	if err := os.Setenv(name, args[1].Value.(string)); err != nil {
+		return NullVariable(), CreateError(ErrorMessage(MSG_INVALID_ENV, QuoteString(name), "setEnv"), startLine)
+	}
+	return NullVariable(), nil
+}
+
+/**
+ * Stop the program with an exit code (0 by default). The exit cannot be caught by "try".
+ * @param args :[]*Variable - The optional exit code.
+ * @return *Variable - Null.
+ * @return error - The exit.
+**/
+func Exit(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) > 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "0 or 1", "exit"), startLine)
+	}
+
+	code := int64(0)
+	if len(args) == 1 {
+		if args[0].Type != "int" {
+			return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "an int", "exit"), startLine)
+		}
+		code = args[0].Value.(int64)
+		if code < 0 || code > 255 {
+			return NullVariable(), CreateError(ErrorMessage(MSG_EXIT_CODE, "exit"), startLine)
+		}
+	}
+
+	return NullVariable(), &ErrorStack{Message: "Exit with code " + strconv.FormatInt(code, 10), Line: startLine, Kind: EXIT, ExitCode: int(code)}
+}
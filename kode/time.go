@@ -0,0 +1,651 @@
+package kode
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Time zones do not depend on the system
+)
+
+// ! Clock : The source of time of a program. Replace Interpreter.Clock to control time (e.g. with a FakeClock in tests).
+type Clock interface {
+	// Get the current time.
+	Now() time.Time
+	// Get the time elapsed since an arbitrary moment; it never goes backwards.
+	Monotonic() time.Duration
+	// Wait for a duration.
+	Sleep(duration time.Duration)
+}
+
+// ! SystemClock : The clock of the system.
+// -------------------------
+// ! start : The origin of the monotonic clock.
+type SystemClock struct {
+	start time.Time
+}
+
+/**
+ * Create a clock reading the time of the system.
+ * @return *SystemClock - The new clock.
+ */
+func NewSystemClock() *SystemClock {
+	return &SystemClock{start: time.Now()}
+}
+
+/**
+ * Get the current time of the system.
+ * @return time.Time - The current time.
+ */
+func (clock *SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+/**
+ * Get the time elapsed since the creation of the clock, measured with the monotonic clock of the system.
+ * @return time.Duration - The elapsed time.
+ */
+func (clock *SystemClock) Monotonic() time.Duration {
+	return time.Since((*clock).start)
+}
+
+/**
+ * Pause the current goroutine.
+ * @param duration : time.Duration - The duration to wait.
+ */
+func (clock *SystemClock) Sleep(duration time.Duration) {
+	time.Sleep(duration)
+}
+
+// ! FakeClock : A clock that only moves when asked to: "sleep" returns immediately and advances it.
+// -------------------------
+// ! now : The current time.
+// -------------------------
+// ! elapsed : The time elapsed since the creation of the clock.
+type FakeClock struct {
+	now     time.Time
+	elapsed time.Duration
+}
+
+/**
+ * Create a clock stopped at a given time.
+ * @param now : time.Time - The current time of the clock. Its location is the local time zone of the program.
+ * @return *FakeClock - The new clock.
+ */
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+/**
+ * Get the current time of the clock, which only changes with Sleep and Advance.
+ * @return time.Time - The current time.
+ */
+func (clock *FakeClock) Now() time.Time {
+	return (*clock).now
+}
+
+/**
+ * Get the time the clock was moved forward since its creation.
+ * @return time.Duration - The elapsed time.
+ */
+func (clock *FakeClock) Monotonic() time.Duration {
+	return (*clock).elapsed
+}
+
+/**
+ * Move the clock forward without waiting.
+ * @param duration : time.Duration - The duration to add to the clock.
+ */
+func (clock *FakeClock) Sleep(duration time.Duration) {
+	clock.Advance(duration)
+}
+
+/**
+ * Move the clock forward.
+ * @param duration : time.Duration - The duration to add to the clock.
+ */
+func (clock *FakeClock) Advance(duration time.Duration) {
+	if duration > 0 {
+		(*clock).now = (*clock).now.Add(duration)
+		(*clock).elapsed += duration
+	}
+}
+
+// Layouts that can be given by name to "formatTime" and "parseTime"
+var TIME_LAYOUTS = map[string]string{
+	"iso":      "%Y-%m-%dT%H:%M:%S%:z",
+	"date":     "%Y-%m-%d",
+	"time":     "%H:%M:%S",
+	"datetime": "%Y-%m-%d %H:%M:%S",
+	"rfc1123":  "%a, %d %b %Y %H:%M:%S %Z",
+}
+
+// Layouts tried in order by "parseTime" without a layout
+var defaultParseLayouts = []string{"iso", "datetime", "date"}
+
+/**
+ * Get the clock of the interpreter running a scope.
+ * @return Clock - The clock.
+ */
+func (scope *Function) clock() Clock {
+	interpreter := scope.GetInterpreter()
+	if (*interpreter).Clock == nil {
+		(*interpreter).Clock = NewSystemClock()
+	}
+	return (*interpreter).Clock
+}
+
+/**
+ * Get a time zone by name (e.g. "Europe/Paris", "UTC" or "Local").
+ * @param name : string - The name of the built-in function, for the error message.
+ * @param zone : *Variable - The name of the time zone.
+ * @return *time.Location - The time zone.
+ * @return error - The error if the zone is unknown.
+ */
+func loadZone(name string, zone *Variable, startLine int) (*time.Location, *ErrorStack) {
+	if zone.Type != "string" {
+		return nil, CreateError(ErrorMessage(MSG_ZONE_STRING, name), startLine)
+	}
+
+	location, err := time.LoadLocation(zone.Value.(string))
+	if err != nil {
+		return nil, CreateError(ErrorMessage(MSG_UNKNOWN_ZONE, QuoteString(zone.Value.(string)), name), startLine)
+	}
+	return location, nil
+}
+
+/**
+ * Check the datetime argument of a built-in function.
+ * @param name : string - The name of the built-in function.
+ * @param args : []*Variable - The arguments of the function.
+ * @param min : int - The minimum number of arguments.
+ * @param max : int - The maximum number of arguments.
+ * @return time.Time - The first argument.
+ * @return error - The error if the arguments are invalid.
+ */
+func checkTimeArgs(name string, args []*Variable, min int, max int, startLine int) (time.Time, *ErrorStack) {
+	if len(args) < min || len(args) > max {
+		if max != min {
+			return time.Time{}, CreateError(ErrorMessage(MSG_EXPECTED_ARGS, strconv.Itoa(min)+" or "+strconv.Itoa(max), name), startLine)
+		}
+		if max == 1 {
+			return time.Time{}, CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", name), startLine)
+		}
+		return time.Time{}, CreateError(ErrorMessage(MSG_EXPECTED_ARGS, strconv.Itoa(min), name), startLine)
+	}
+
+	value, ok := args[0].Value.(time.Time)
+	if !ok {
+		return time.Time{}, CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "a datetime", name), startLine)
+	}
+	return value, nil
+}
+
+/**
+ * Convert a number of seconds or milliseconds to a duration.
+ * @param value : *Variable - The int or float.
+ * @param unit : time.Duration - The unit of the number.
+ * @return time.Duration - The duration.
+ * @return bool - False if the value is not a number.
+ */
+func toDuration(value *Variable, unit time.Duration) (time.Duration, bool) {
+	switch number := value.Value.(type) {
+	case int64:
+		return time.Duration(number) * unit, true
+	case float64:
+		return time.Duration(number * float64(unit)), true
+	default:
+		return 0, false
+	}
+}
+
+/**
+ * Get the current date and time.
+ * e.g. print(now().format("%H:%M"))
+ * @return *Variable - The current datetime.
+**/
+func Now(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "0", "now"), startLine)
+	}
+
+	variable := CreateVariable(scope.clock().Now())
+	return &variable, nil
+}
+
+/**
+ * Get the current Unix time (seconds since January 1, 1970 UTC).
+ * @return *Variable - The number of seconds.
+**/
+func Unix(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "0", "unix"), startLine)
+	}
+
+	variable := CreateVariable(scope.clock().Now().Unix())
+	return &variable, nil
+}
+
+/**
+ * Get the time elapsed since an arbitrary moment in milliseconds, to measure durations.
+ * Unlike "now", it is not affected by changes of the system time.
+ * e.g. val start = monotonic()
+ * @return *Variable - The number of milliseconds.
+**/
+func Monotonic(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "0", "monotonic"), startLine)
+	}
+
+	variable := CreateVariable(float64(scope.clock().Monotonic()) / float64(time.Millisecond))
+	return &variable, nil
+}
+
+/**
+ * Pause the program.
+ * @param args :[]*Variable - The number of milliseconds to wait.
+ * @return *Variable - Null.
+**/
+func Sleep(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGUMENT, "1", "sleep"), startLine)
+	}
+
+	duration, ok := toDuration(args[0], time.Millisecond)
+	if !ok {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_MUST, "an int or a float", "sleep"), startLine)
+	}
+	if duration < 0 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_POSITIVE_DURATION, "sleep"), startLine)
+	}
+
+	scope.clock().Sleep(duration)
+	return NullVariable(), nil
+}
+
+/**
+ * Create a datetime. The time zone is the local one if it is not given.
+ * e.g. datetime(2024, 2, 29, 12, 30, 0, "Europe/Paris")
+ * @param args :[]*Variable - The year, month and day, then optionally the hour, minute and second, then the time zone.
+ * @return *Variable - The datetime.
+ * @return error - The error if one occurs.
+**/
+func Datetime(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 3 && len(args) != 6 && len(args) != 7 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "3, 6 or 7", "datetime"), startLine)
+	}
+
+	fields := [6]int{}
+	for i := 0; i < len(args) && i < 6; i++ {
+		if args[i].Type != "int" {
+			return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, strconv.Itoa(i+1), "an int", "datetime"), startLine)
+		}
+		fields[i] = int(args[i].Value.(int64))
+	}
+
+	location := scope.clock().Now().Location()
+	if len(args) == 7 {
+		var err *ErrorStack
+		if location, err = loadZone("datetime", args[6], startLine); err != nil {
+			return NullVariable(), err
+		}
+	}
+
+	// Out of range values are not normalized (e.g. February 30)
+	daysInMonth := time.Date(fields[0], time.Month(fields[1])+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if fields[1] < 1 || fields[1] > 12 || fields[2] < 1 || fields[2] > daysInMonth ||
+		fields[3] < 0 || fields[3] > 23 || fields[4] < 0 || fields[4] > 59 || fields[5] < 0 || fields[5] > 59 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_INVALID_DATE, "datetime"), startLine)
+	}
+	value := time.Date(fields[0], time.Month(fields[1]), fields[2], fields[3], fields[4], fields[5], 0, location)
+
+	variable := CreateVariable(value)
+	return &variable, nil
+}
+
+/**
+ * Create a datetime from a Unix time. The time zone is the local one if it is not given.
+ * @param args :[]*Variable - The number of seconds since January 1, 1970 UTC and the optional time zone.
+ * @return *Variable - The datetime.
+ * @return error - The error if one occurs.
+**/
+func FromUnix(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) != 1 && len(args) != 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "1 or 2", "fromUnix"), startLine)
+	}
+
+	duration, ok := toDuration(args[0], time.Second)
+	if !ok {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "1", "an int or a float", "fromUnix"), startLine)
+	}
+
+	location := scope.clock().Now().Location()
+	if len(args) == 2 {
+		var err *ErrorStack
+		if location, err = loadZone("fromUnix", args[1], startLine); err != nil {
+			return NullVariable(), err
+		}
+	}
+
+	variable := CreateVariable(time.Unix(0, 0).Add(duration).In(location))
+	return &variable, nil
+}
+
+/**
+ * Format a datetime with a layout: a layout name ("iso", "date", "time", "datetime", "rfc1123") or directives
+ * (%Y year, %y 2-digit year, %m month, %d day, %e day padded with a space, %H hour, %I 12-hour, %M minute, %S second,
+ * %p AM/PM, %b/%B month name, %a/%A weekday name, %j day of the year, %Z zone, %z offset, %:z offset with colon, %% "%").
+ * e.g. formatTime(now(), "%d/%m/%Y")
+ * @param args :[]*Variable - The datetime and the optional layout ("iso" by default).
+ * @return *Variable - The formatted datetime.
+ * @return error - The error if one occurs.
+**/
+func FormatTime(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	value, err := checkTimeArgs("formatTime", args, 1, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	layout := "iso"
+	if len(args) == 2 {
+		if args[1].Type != "string" {
+			return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "a string", "formatTime"), startLine)
+		}
+		layout = args[1].Value.(string)
+	}
+
+	formatted, ok := formatTimeLayout(value, layout)
+	if !ok {
+		return NullVariable(), CreateError(ErrorMessage(MSG_INVALID_LAYOUT, QuoteString(layout), "formatTime"), startLine)
+	}
+
+	variable := CreateVariable(formatted)
+	return &variable, nil
+}
+
+/**
+ * Format a datetime with a layout (see FormatTime).
+ * @param value : time.Time - The datetime.
+ * @param layout : string - The layout name or directives.
+ * @return string - The formatted datetime.
+ * @return bool - False if the layout has an unknown directive.
+ */
+func formatTimeLayout(value time.Time, layout string) (string, bool) {
+	if named, exists := TIME_LAYOUTS[layout]; exists {
+		layout = named
+	}
+
+	builder := strings.Builder{}
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' {
+			builder.WriteByte(layout[i])
+			continue
+		}
+
+		directive, size := timeDirective(layout[i+1:])
+		if size == 0 {
+			return "", false
+		}
+		i += size
+
+		switch directive {
+		case "%":
+			builder.WriteString("%")
+		case "j":
+			builder.WriteString(padNumber(value.YearDay(), 3))
+		default:
+			builder.WriteString(value.Format(TIME_DIRECTIVES[directive]))
+		}
+	}
+	return builder.String(), true
+}
+
+// Directives of the time layouts and the equivalent Go layouts
+var TIME_DIRECTIVES = map[string]string{
+	"Y": "2006", "y": "06", "m": "01", "d": "02", "e": "_2", "H": "15", "I": "03", "M": "04", "S": "05",
+	"p": "PM", "b": "Jan", "B": "January", "a": "Mon", "A": "Monday", "Z": "MST", "z": "-0700", ":z": "-07:00",
+	"j": "", "%": "",
+}
+
+/**
+ * Read the directive at the start of a layout, after the "%".
+ * @param layout : string - The rest of the layout.
+ * @return string - The directive (e.g. "Y").
+ * @return int - The length of the directive, or 0 if it is unknown.
+ */
+func timeDirective(layout string) (string, int) {
+	for _, size := range []int{2, 1} {
+		if len(layout) >= size {
+			if _, exists := TIME_DIRECTIVES[layout[:size]]; exists {
+				return layout[:size], size
+			}
+		}
+	}
+	return "", 0
+}
+
+/**
+ * Pad a number with zeros.
+ * @param number : int - The number.
+ * @param width : int - The minimum number of digits.
+ * @return string - The padded number.
+ */
+func padNumber(number int, width int) string {
+	str := strconv.Itoa(number)
+	if len(str) < width {
+		str = strings.Repeat("0", width-len(str)) + str
+	}
+	return str
+}
+
+/**
+ * Read a datetime from a string. Without a layout, the "iso", "datetime" and "date" layouts are tried.
+ * The time zone is used if the string has no offset; it is the local one by default.
+ * e.g. parseTime("29/02/2024", "%d/%m/%Y", "UTC")
+ * @param args :[]*Variable - The string, the optional layout (see formatTime) and the optional time zone.
+ * @return *Variable - The datetime.
+ * @return error - The error if the string does not match the layout.
+**/
+func ParseTime(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	if len(args) < 1 || len(args) > 3 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_EXPECTED_ARGS, "1, 2 or 3", "parseTime"), startLine)
+	}
+
+	for i, arg := range args {
+		if arg.Type != "string" {
+			return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, strconv.Itoa(i+1), "a string", "parseTime"), startLine)
+		}
+	}
+	str := args[0].Value.(string)
+
+	layouts := defaultParseLayouts
+	if len(args) >= 2 {
+		layouts = []string{args[1].Value.(string)}
+	}
+
+	location := scope.clock().Now().Location()
+	if len(args) == 3 {
+		var err *ErrorStack
+		if location, err = loadZone("parseTime", args[2], startLine); err != nil {
+			return NullVariable(), err
+		}
+	}
+
+	for _, layout := range layouts {
+		goLayout, ok := goTimeLayout(layout)
+		if !ok {
+			return NullVariable(), CreateError(ErrorMessage(MSG_INVALID_LAYOUT, QuoteString(layout), "parseTime"), startLine)
+		}
+
+		if value, err := time.ParseInLocation(goLayout, str, location); err == nil {
+			variable := CreateVariable(value)
+			return &variable, nil
+		}
+	}
+
+	if len(args) >= 2 {
+		return NullVariable(), CreateError(ErrorMessage(MSG_PARSE_LAYOUT, QuoteString(str), QuoteString(layouts[0]), "parseTime"), startLine)
+	}
+	return NullVariable(), CreateError(ErrorMessage(MSG_PARSE_TIME, QuoteString(str), "parseTime"), startLine)
+}
+
+/**
+ * Convert a layout (see FormatTime) to a Go layout.
+ * @param layout : string - The layout name or directives.
+ * @return string - The Go layout.
+ * @return bool - False if the layout has an unknown directive or one that cannot be parsed.
+ */
+func goTimeLayout(layout string) (string, bool) {
+	if named, exists := TIME_LAYOUTS[layout]; exists {
+		layout = named
+	}
+
+	builder := strings.Builder{}
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' {
+			builder.WriteByte(layout[i])
+			continue
+		}
+
+		directive, size := timeDirective(layout[i+1:])
+		if size == 0 || directive == "j" {
+			return "", false
+		}
+		i += size
+
+		switch directive {
+		case "%":
+			builder.WriteString("%")
+		case "z", ":z":
+			// Also accept "Z" for UTC
+			builder.WriteString("Z" + TIME_DIRECTIVES[directive][1:])
+		default:
+			builder.WriteString(TIME_DIRECTIVES[directive])
+		}
+	}
+	return builder.String(), true
+}
+
+/**
+ * Add days to a datetime. The time of the day is kept across changes to daylight saving time.
+ * @param args :[]*Variable - The datetime and the number of days (negative to go back).
+ * @return *Variable - The new datetime.
+**/
+func AddDays(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	value, err := checkTimeArgs("addDays", args, 2, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	if args[1].Type != "int" {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "an int", "addDays"), startLine)
+	}
+
+	variable := CreateVariable(value.AddDate(0, 0, int(args[1].Value.(int64))))
+	return &variable, nil
+}
+
+/**
+ * Add seconds to a datetime.
+ * @param args :[]*Variable - The datetime and the number of seconds (negative to go back).
+ * @return *Variable - The new datetime.
+**/
+func AddSeconds(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	value, err := checkTimeArgs("addSeconds", args, 2, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	duration, ok := toDuration(args[1], time.Second)
+	if !ok {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "an int or a float", "addSeconds"), startLine)
+	}
+
+	variable := CreateVariable(value.Add(duration))
+	return &variable, nil
+}
+
+/**
+ * Get the number of seconds between two datetimes.
+ * e.g. diffSeconds(end, start)
+ * @param args :[]*Variable - The two datetimes.
+ * @return *Variable - The first datetime minus the second one, in seconds.
+**/
+func DiffSeconds(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	value, err := checkTimeArgs("diffSeconds", args, 2, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	other, ok := args[1].Value.(time.Time)
+	if !ok {
+		return NullVariable(), CreateError(ErrorMessage(MSG_ARGUMENT_N_MUST, "2", "a datetime", "diffSeconds"), startLine)
+	}
+
+	variable := CreateVariable(value.Sub(other).Seconds())
+	return &variable, nil
+}
+
+/**
+ * Convert a datetime to another time zone. The moment is the same, only the displayed time changes.
+ * e.g. toZone(now(), "America/Montreal")
+ * @param args :[]*Variable - The datetime and the name of the time zone.
+ * @return *Variable - The datetime in the time zone.
+ * @return error - The error if the time zone is unknown.
+**/
+func ToZone(args []*Variable, startLine int) (*Variable, *ErrorStack) {
+	value, err := checkTimeArgs("toZone", args, 2, 2, startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	location, err := loadZone("toZone", args[1], startLine)
+	if err != nil {
+		return NullVariable(), err
+	}
+
+	variable := CreateVariable(value.In(location))
+	return &variable, nil
+}
+
+/**
+ * Create a method returning a part of a datetime (e.g. its year).
+ * @param name : string - The name of the method.
+ * @param part : func(time.Time) interface{} - Get the part of the datetime.
+ * @return Method - The method.
+ */
+func timeAccessor(name string, part func(time.Time) interface{}) Method {
+	return func(scope *Function, args []*Variable, startLine int) (*Variable, *ErrorStack) {
+		value, err := checkTimeArgs(name, args, 1, 1, startLine)
+		if err != nil {
+			return NullVariable(), err
+		}
+
+		variable := CreateVariable(part(value))
+		return &variable, nil
+	}
+}
+
+/**
+ * Get the methods of datetimes.
+ * @return map[string]Method - The methods by name.
+ */
+func timeMethods() map[string]Method {
+	return map[string]Method{
+		"year":       timeAccessor("year", func(t time.Time) interface{} { return int64(t.Year()) }),
+		"month":      timeAccessor("month", func(t time.Time) interface{} { return int64(t.Month()) }),
+		"day":        timeAccessor("day", func(t time.Time) interface{} { return int64(t.Day()) }),
+		"hour":       timeAccessor("hour", func(t time.Time) interface{} { return int64(t.Hour()) }),
+		"minute":     timeAccessor("minute", func(t time.Time) interface{} { return int64(t.Minute()) }),
+		"second":     timeAccessor("second", func(t time.Time) interface{} { return int64(t.Second()) }),
+		"yearDay":    timeAccessor("yearDay", func(t time.Time) interface{} { return int64(t.YearDay()) }),
+		"weekday":    timeAccessor("weekday", func(t time.Time) interface{} { return t.Weekday().String() }),
+		"monthName":  timeAccessor("monthName", func(t time.Time) interface{} { return t.Month().String() }),
+		"zone":       timeAccessor("zone", func(t time.Time) interface{} { return t.Location().String() }),
+		"unix":       timeAccessor("unix", func(t time.Time) interface{} { return t.Unix() }),
+		"format":     BuiltInMethod("formatTime"),
+		"addDays":    BuiltInMethod("addDays"),
+		"addSeconds": BuiltInMethod("addSeconds"),
+		"diff":       BuiltInMethod("diffSeconds"),
+		"toZone":     BuiltInMethod("toZone"),
+	}
+}
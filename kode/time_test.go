@@ -0,0 +1,35 @@
+package kode
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	interpreter := NewInterpreter()
+	clock := NewFakeClock(time.Date(2024, 2, 29, 12, 30, 0, 0, time.UTC))
+	interpreter.Clock = clock
+	output := bytes.Buffer{}
+	interpreter.Output = &output
+
+	code := `val start = monotonic()
+print(now().format("%Y-%m-%d %H:%M:%S"))
+sleep(1500)
+print(monotonic() - start)
+print(now().format("%Y-%m-%d %H:%M:%S"))
+print(unix())`
+	if err := interpreter.Run(code); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "2024-02-29 12:30:00\n1500\n2024-02-29 12:30:01\n1709209801\n"
+	if output.String() != expected {
+		t.Errorf("expected %q, got %q", expected, output.String())
+	}
+
+	clock.Advance(time.Hour)
+	if clock.Monotonic() != time.Hour+1500*time.Millisecond {
+		t.Errorf("expected the clock to be advanced, got %v", clock.Monotonic())
+	}
+}
@@ -0,0 +1,152 @@
+package kode
+
+// Delimiters of the tokens of a statement
+var STATEMENT_DELIMITERS = []string{" ", "\t", "\r", "[", "]", "!=", "==", ">=", "<=", "=", ":=", "#", "\"", "\\\"", ",", ".", "(", ")", "\""}
+
+// ! Span : Where a piece of code is in its line, as byte offsets from the start of the line (the end is excluded).
+// An empty span (e.g. the zero value) points at nothing.
+// -------------------------
+// ! Start : The offset of the first byte of the code.
+// -------------------------
+// ! End : The offset after the last byte of the code.
+type Span struct {
+	Start int
+	End   int
+}
+
+/**
+ * Check if a span points at nothing.
+ * @return bool - True if the span is empty.
+ */
+func (span Span) IsEmpty() bool {
+	return span.End <= span.Start
+}
+
+/**
+ * Get the span from the start of a span to the end of another one, e.g. from a name to its closing bracket.
+ * @param end : Span - The last span.
+ * @return Span - The span covering both spans.
+ */
+func (span Span) To(end Span) Span {
+	return Span{span.Start, end.End}
+}
+
+// ! Tokens : The tokens of a piece of a line of code, read one after another from the queue, and where each of them
+// is in the line so that the errors can point at the code.
+// -------------------------
+// ! Queue : The tokens left to read.
+// -------------------------
+// ! Code : The code.
+// -------------------------
+// ! Offset : The byte offset of the code in its line.
+// -------------------------
+// ! spans : The span of each token, read or not.
+type Tokens struct {
+	Queue
+	Code   string
+	Offset int
+	spans  []Span
+}
+
+/**
+ * Create the tokens of a piece of a line of code.
+ * @param code : string - The code.
+ * @param offset : int - The byte offset of the code in its line.
+ * @param tokens : []string - The tokens of the code.
+ * @param spans : []Span - The span of each token in the line (see InlineParseSpans).
+ * @return *Tokens - The tokens, none of them read.
+ */
+func NewTokens(code string, offset int, tokens []string, spans []Span) *Tokens {
+	queue := Queue{}
+	for _, token := range tokens {
+		queue.Push(token)
+	}
+	return &Tokens{Queue: queue, Code: code, Offset: offset, spans: spans}
+}
+
+/**
+ * Split a line of code into the tokens of a statement (see STATEMENT_DELIMITERS).
+ * @param line : string - The line of code.
+ * @return *Tokens - The tokens of the line.
+ */
+func NewLineTokens(line string) *Tokens {
+	tokens, spans := InlineParseSpans(line, 0, STATEMENT_DELIMITERS, true)
+	return NewTokens(line, 0, tokens, spans)
+}
+
+/**
+ * Get the index of the next token to read.
+ * @return int - The index of the token, or the number of tokens if they are all read.
+ */
+func (tokens *Tokens) Index() int {
+	return len((*tokens).spans) - len((*tokens).Queue)
+}
+
+/**
+ * Get the span of a token.
+ * @param index : int - The index of the token.
+ * @return Span - The span of the token, or an empty span at the end of the code if there is no such token.
+ */
+func (tokens *Tokens) SpanAt(index int) Span {
+	if index < 0 || index >= len((*tokens).spans) {
+		end := (*tokens).Offset + len((*tokens).Code)
+		return Span{end, end}
+	}
+	return (*tokens).spans[index]
+}
+
+/**
+ * Get the span of the last read token.
+ * @return Span - The span of the token.
+ */
+func (tokens *Tokens) Last() Span {
+	return tokens.SpanAt(tokens.Index() - 1)
+}
+
+/**
+ * Get the span of consecutive tokens.
+ * @param from : int - The index of the first token.
+ * @param to : int - The index after the last token.
+ * @return Span - The span from the first token to the last one, empty if there is no token between the indexes.
+ */
+func (tokens *Tokens) Between(from int, to int) Span {
+	if from >= to {
+		start := tokens.SpanAt(from).Start
+		return Span{start, start}
+	}
+	return tokens.SpanAt(from).To(tokens.SpanAt(to - 1))
+}
+
+/**
+ * Get the code of a span.
+ * @param span : Span - A span of the code.
+ * @return string - The code of the span (empty for an empty span).
+ */
+func (tokens *Tokens) Text(span Span) string {
+	if span.IsEmpty() {
+		return ""
+	}
+	return (*tokens).Code[span.Start-(*tokens).Offset : span.End-(*tokens).Offset]
+}
+
+/**
+ * Read the tokens left up to the comment of the line, if any.
+ * @return Span - The span of the read tokens, empty if there is none.
+ */
+func (tokens *Tokens) Rest() Span {
+	from := tokens.Index()
+	to := from
+	isInString := false
+	for !tokens.IsEmpty() {
+		token, _ := tokens.Pop()
+		if token.(string) == "\"" {
+			isInString = !isInString
+		}
+		if token.(string) == "#" && !isInString {
+			tokens.Clear()
+			break
+		}
+		to = tokens.Index()
+	}
+	return tokens.Between(from, to)
+}
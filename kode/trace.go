@@ -0,0 +1,200 @@
+package kode
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Number of consecutive frames of a function kept in a call stack before the next ones are elided
+const TRACE_REPEATED_FRAMES = 3
+
+// Maximum number of characters of an argument shown in a call stack
+const TRACE_ARGUMENT_LENGTH = 20
+
+// ! Frame : A function call of the call stack of an error.
+// -------------------------
+// ! Function : The name of the called function.
+// -------------------------
+// ! Arguments : The arguments of the call (e.g. "5, \"abc\""), if the interpreter shows them (see TraceArguments).
+// -------------------------
+// ! File, Line, Column : The location of the call.
+// -------------------------
+// ! Elided : The number of frames replaced by this one (0 for an actual call), e.g. repeated recursive calls.
+// -------------------------
+// ! span : Where the call is in its line, used to find the column.
+type Frame struct {
+	Function  string `json:"function"`
+	Arguments string `json:"arguments,omitempty"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	Elided    int    `json:"elided,omitempty"`
+	span      Span
+}
+
+// ! callFrame : A function call being run.
+// -------------------------
+// ! name : The name of the function.
+// -------------------------
+// ! args : The arguments of the call.
+// -------------------------
+// ! line : The line of the call.
+// -------------------------
+// ! span : Where the call is in its line.
+type callFrame struct {
+	name string
+	args []*Variable
+	line int
+	span Span
+}
+
+/**
+ * Call a function from a scope. The call is recorded in the call stack of the interpreter, and the errors of the call
+ * keep the call stack at the moment they occur.
+ * @param function : *Function - The function to run (a copy of the declared function with its variables).
+ * @param name : string - The name used to call the function.
+ * @param args : []*Variable - The arguments.
+ * @param depth : int64 - The recursion depth of the call.
+ * @param line : int - The line of the call.
+ * @param at : Span - Where the call is in its line.
+ * @return *Variable - The returned value.
+ * @return *ErrorStack - The error of the call, if any.
+ */
+func (scope *Function) CallFunction(function *Function, name string, args []*Variable, depth int64, line int, at Span) (*Variable, *ErrorStack) {
+	interpreter := scope.GetInterpreter()
+	(*interpreter).calls = append((*interpreter).calls, callFrame{name: name, args: args, line: line, span: at})
+	defer func() {
+		(*interpreter).calls = (*interpreter).calls[:len((*interpreter).calls)-1]
+	}()
+
+	instance, _, err := (*function).Run(args, map[string]*Variable{}, depth, line)
+	if err != nil {
+		if cause := err.Cause(); (*cause).Trace == nil {
+			(*cause).Trace = interpreter.CallStack()
+		}
+		return nil, err
+	}
+	return instance, nil
+}
+
+/**
+ * Get where the function call being run is in its line.
+ * @return Span - The span of the call, or an empty span outside of any call.
+ */
+func (interpreter *Interpreter) callSpan() Span {
+	if len((*interpreter).calls) == 0 {
+		return Span{}
+	}
+	return (*interpreter).calls[len((*interpreter).calls)-1].span
+}
+
+/**
+ * Get the call stack of the interpreter, from the first call to the last one. Repeated frames of a function
+ * (e.g. a recursion) and the frames past MaxTraceFrames are elided.
+ * @return []Frame - The frames.
+ */
+func (interpreter *Interpreter) CallStack() []Frame {
+	frames := []Frame{}
+	for i, call := range (*interpreter).calls {
+
+		// Elide the frames of a function repeated too many times in a row, except the last one
+		isLast := i+1 == len((*interpreter).calls) || (*interpreter).calls[i+1].name != call.name
+		if !isLast && i >= TRACE_REPEATED_FRAMES && isRepeatedCall((*interpreter).calls[i-TRACE_REPEATED_FRAMES:i+1]) {
+			if last := &frames[len(frames)-1]; (*last).Elided > 0 {
+				(*last).Elided++
+			} else {
+				frames = append(frames, Frame{Function: call.name, Elided: 1})
+			}
+			continue
+		}
+
+		frame := Frame{Function: call.name, Line: call.line, span: call.span}
+		if (*interpreter).TraceArguments {
+			frame.Arguments = summarizeArguments(call.args)
+		}
+		frames = append(frames, frame)
+	}
+
+	// Keep the first and last frames
+	if limit := (*interpreter).MaxTraceFrames; limit > 0 && len(frames) > limit {
+		first, last := frames[:limit/2], frames[len(frames)-(limit-limit/2):]
+		middle := Frame{}
+		for _, frame := range frames[len(first) : len(frames)-len(last)] {
+			middle.Elided += frame.Elided
+			if frame.Elided == 0 {
+				middle.Elided++
+			}
+		}
+
+		// Merge the elided frames next to the middle
+		if len(first) > 0 && first[len(first)-1].Elided > 0 {
+			middle.Elided += first[len(first)-1].Elided
+			first = first[:len(first)-1]
+		}
+		if len(last) > 0 && last[0].Elided > 0 {
+			middle.Elided += last[0].Elided
+			last = last[1:]
+		}
+		frames = append(append(append([]Frame{}, first...), middle), last...)
+	}
+	return frames
+}
+
+/**
+ * Check if calls are all calls of the same function.
+ * @param calls : []callFrame - The calls.
+ * @return bool - True if every call has the same name.
+ */
+func isRepeatedCall(calls []callFrame) bool {
+	for _, call := range calls[1:] {
+		if call.name != calls[0].name {
+			return false
+		}
+	}
+	return true
+}
+
+/**
+ * Summarize the arguments of a call, e.g. 5, "abc", [1, 2, 3].
+ * @param args : []*Variable - The arguments.
+ * @return string - The summary, with long arguments shortened.
+ */
+func summarizeArguments(args []*Variable) string {
+	summaries := []string{}
+	for _, arg := range args {
+		summary := []rune(ReprVariable(*arg))
+		if len(summary) > TRACE_ARGUMENT_LENGTH {
+			summary = append(summary[:TRACE_ARGUMENT_LENGTH-3], []rune("...")...)
+		}
+		summaries = append(summaries, string(summary))
+	}
+	return strings.Join(summaries, ", ")
+}
+
+/**
+ * Render the call stack of an error.
+ * @param frames : []Frame - The frames, from the first call to the last one.
+ * @param language : string - The language of the call stack (see LANGUAGES).
+ * @return string - The call stack, one call per line.
+ */
+func renderCallStack(frames []Frame, language string) string {
+	txt := Text(language, MSG_CALL_STACK)
+	for _, frame := range frames {
+		if frame.Elided > 0 {
+			more := Text(language, MSG_ELIDED_FRAME, strconv.Itoa(frame.Elided))
+			switch {
+			case frame.Elided > 1 && frame.Function != "":
+				more = Text(language, MSG_ELIDED_FRAMES_OF, strconv.Itoa(frame.Elided), frame.Function)
+			case frame.Elided > 1:
+				more = Text(language, MSG_ELIDED_FRAMES, strconv.Itoa(frame.Elided))
+			case frame.Function != "":
+				more = Text(language, MSG_ELIDED_FRAME_OF, strconv.Itoa(frame.Elided), frame.Function)
+			}
+			txt += "\n  " + more
+			continue
+		}
+
+		txt += "\n  " + frame.Function + "(" + frame.Arguments + ") " + location(frame.File, frame.Line, frame.Column, language)
+	}
+	return txt
+}
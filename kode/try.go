@@ -0,0 +1,157 @@
+package kode
+
+// ! TryBlock : A block of code whose errors are caught.
+// -------------------------
+// ! Code : The code that may fail.
+// -------------------------
+// ! ErrorName : The name of the variable holding the caught error (optional).
+// -------------------------
+// ! CatchCode : The code run when an error is caught.
+// -------------------------
+// ! CatchIndex : The line number of the "catch" statement.
+type TryBlock struct {
+	Code       string
+	ErrorName  string
+	CatchCode  string
+	CatchIndex int
+}
+
+/**
+ * Parse a try block:
+ * try
+ *   ...
+ * catch err
+ *   ...
+ * end try
+ * @param tokens : *Tokens - The tokens after "try".
+ * @param currentLine : int - The current line number of the "try" token.
+ * @param lines : []string - The lines of the current scope.
+ * @param startLine : int - The line of the program of the first line of the scope.
+ * @return TryBlock - The parsed try block.
+ * @return int - The line of the "end try" statement.
+ * @return error - The error if any.
+ */
+func ParseTryBlock(tokens *Tokens, currentLine int, lines []string, startLine int) (TryBlock, int, *ErrorStack) {
+
+	if rest := tokens.Rest(); !rest.IsEmpty() {
+		return TryBlock{}, currentLine, CreateSyntaxError(ErrorMessage(MSG_UNEXPECTED_AFTER, tokens.Text(rest), "try"), currentLine+startLine).At(rest)
+	}
+
+	block := TryBlock{CatchIndex: -1}
+	startIndex := currentLine
+	currentLine++          // Skip to next line to avoid including the "try" statement in the code
+	foundBoundary := false // Flag to indicate if the boundary of the block has been found
+	nestedBlocksCount := 0 // Keep track of the number of nested blocks
+
+	for currentLine < len(lines) {
+
+		// Parse the current line
+		parsed := []string{}
+		for _, token := range InlineParse(lines[currentLine], []string{" ", "\t", "#"}, true) {
+			if token == "#" {
+				break
+			}
+			if token != " " && token != "\t" && token != "\r" && token != "" {
+				parsed = append(parsed, token)
+			}
+		}
+
+		if len(parsed) > 0 && parsed[0] == "try" {
+
+			nestedBlocksCount++
+
+		} else if len(parsed) > 0 && parsed[0] == "catch" && nestedBlocksCount == 0 {
+
+			if block.CatchIndex >= 0 {
+				return TryBlock{}, currentLine, CreateSyntaxError(ErrorMessage(MSG_ONE_CATCH), currentLine+startLine)
+			}
+			if len(parsed) > 2 {
+				return TryBlock{}, currentLine, CreateSyntaxError(ErrorMessage(MSG_EXPECTED_CATCH), currentLine+startLine)
+			}
+			if len(parsed) == 2 {
+				if !HasValidVariableName(parsed[1]) {
+					return TryBlock{}, currentLine, CreateSyntaxError(ErrorMessage(MSG_VARIABLE_NAME, parsed[1]), currentLine+startLine)
+				}
+				block.ErrorName = parsed[1]
+			}
+			block.CatchIndex = currentLine
+			currentLine++
+			continue
+
+		} else if len(parsed) > 1 && parsed[0] == "end" && parsed[1] == "try" {
+
+			if nestedBlocksCount == 0 {
+				foundBoundary = true
+				break
+			}
+			nestedBlocksCount--
+
+		}
+
+		// Add the line to the try or the catch code
+		if block.CatchIndex >= 0 {
+			block.CatchCode += lines[currentLine] + "\n"
+		} else {
+			block.Code += lines[currentLine] + "\n"
+		}
+		currentLine++
+	}
+
+	if !foundBoundary {
+		return TryBlock{}, currentLine, unclosedBlockError("try", "try", lines, startIndex, startLine)
+	}
+
+	return block, currentLine, nil
+}
+
+/**
+ * Create the value of a caught error: an object with the message and the code of the error and its location.
+ * The message is in the language the error is shown in (see Localize).
+ * @param err : *ErrorStack - The caught error.
+ * @return Variable - The error object, e.g. error{code: "E0500", column: 9, line: 3, message: "Cannot divide by zero"}.
+ */
+func CreateErrorObject(err *ErrorStack) Variable {
+
+	cause := err.Cause()
+
+	message := CreateVariable(trimErrorPrefix((*cause).Message))
+	line := CreateVariable(int64((*cause).Line))
+	column := CreateVariable(int64((*cause).Column))
+	code := CreateVariable(cause.ErrorCode())
+	return CreateObject("error", map[string]*Variable{"message": &message, "line": &line, "column": &column, "code": &code})
+}
+
+/**
+ * Run a try block. If the code fails, the error is caught and the catch code is run.
+ * Errors are ignored if there is no catch code. Calls to "exit" are not caught.
+ * @param block : TryBlock - The try block.
+ * @param depth : int64 - The recursion depth.
+ * @param line : int - The line of the "try" statement in the program.
+ * @return *Variable - The returned value if the block returns.
+ * @return int - 1 if the block returns, 2 if it breaks a loop, 0 otherwise.
+ * @return error - The error if the catch code fails.
+ */
+func (scope *Function) RunTry(block TryBlock, depth int64, line int) (*Variable, int, *ErrorStack) {
+	tryScope := CreateFunction("try", line, []Argument{}, (*scope).Variables, "val", scope, block.Code)
+	returnValue, toReturn, err := tryScope.Run([]*Variable{}, map[string]*Variable{}, depth, line)
+	if err == nil {
+		return returnValue, toReturn, nil
+	}
+
+	// Exiting the program cannot be caught
+	if err.Cause().Kind == EXIT {
+		return NullVariable(), 0, err
+	}
+
+	vars := map[string]*Variable{}
+	if block.ErrorName != "" {
+		err.Locate(scope.GetInterpreter().source, KEYWORD_ALIASES[scope.GetInterpreter().keywords])
+		err.Localize(scope.GetInterpreter().Language)
+		errorObject := CreateErrorObject(err)
+		vars[block.ErrorName] = &errorObject
+	}
+
+	catchLine := (*scope).LineAt(block.CatchIndex)
+	catchScope := CreateFunction("catch", catchLine, []Argument{}, (*scope).Variables, "val", scope, block.CatchCode)
+	return catchScope.Run([]*Variable{}, vars, depth, catchLine)
+}
@@ -1,7 +1,9 @@
 package kode
 
 import (
+	"reflect"
 	"strconv"
+	"unicode"
 )
 
 /**
@@ -10,6 +12,11 @@ import (
  * @return bool - True if the string is a number.
  */
 func IsNumber(str string) bool {
+	// Words accepted by strconv (e.g. "Inf" or "NaN") are not number literals
+	if str == "" || !(unicode.IsDigit(rune(str[0])) || str[0] == '.') {
+		return false
+	}
+
 	// Check if parse float causes an error
 	_, err := strconv.ParseFloat(str, 64)
 	return err == nil
@@ -36,52 +43,6 @@ func SkipWhileTokenNext(queue *Queue, value string) {
 	}
 }
 
-// ? Note by Eduard: I don't know if this is the best way to do this, but for now its works.
-/**
- * Reassemble the tokens in a Queue into a string.
- * @param queue : Queue - The queue to reassemble.
- * @return string - The reassembled string.
- */
-func InlineQueueToString(queue *Queue) string {
-	value := ""
-	isInString := false
-	for !(*queue).IsEmpty() {
-		tokenValue, _ := (*queue).Peek()
-
-		// Enter or exist a string
-		if tokenValue.(string) == "\"" {
-			isInString = !isInString
-		}
-
-		// Exit if a comment is found.
-		if tokenValue.(string) == "#" {
-			break
-		}
-
-		// In order to keep the "#" in the queue if it exists, we only pop the next token now.
-		(*queue).Pop()
-
-		// If not in a string, add an additional space for proper parsing afterwards
-		if !isInString {
-
-			// ! No longer needed
-			// peekedValue, _ := (*queue).Peek()
-
-			// if tokenValue.(string) == "=" && peekedValue.(string) == "=" {
-			// 	value += tokenValue.(string)
-			// } else {
-			// 	value += tokenValue.(string) + " "
-			// }
-
-			value += tokenValue.(string) + " "
-
-		} else {
-			value += tokenValue.(string)
-		}
-	}
-	return value
-}
-
 /**
  * Copy a variable map.
  * @param map : map[string]*Variable - The map to copy.
@@ -97,17 +58,177 @@ func CopyVariableMap(originalMap map[string]*Variable) map[string]*Variable {
 	return newMap
 }
 
+/**
+ * Copy a function (its variables map is copied, but not the variables themselves).
+ * @param originalFunction : *Function - The function to copy.
+ * @return *Function - The copied function.
+ */
 func CopyFunction(originalFunction *Function) *Function {
 	// Create new function
 
 	newVars := CopyVariableMap((*originalFunction).Variables)
 	newFunction := &Function{
-		Code:      (*originalFunction).Code,
-		Return:    (*originalFunction).Return,
-		Arguments: (*originalFunction).Arguments,
-		Variables: newVars,
-		Parent:    (*originalFunction).Parent,
-		Name:      (*originalFunction).Name,
+		Code:        (*originalFunction).Code,
+		Return:      (*originalFunction).Return,
+		Arguments:   (*originalFunction).Arguments,
+		Variables:   newVars,
+		Parent:      (*originalFunction).Parent,
+		Name:        (*originalFunction).Name,
+		Index:       (*originalFunction).Index,
+		Interpreter: (*originalFunction).Interpreter,
+		Fields:      (*originalFunction).Fields,
 	}
 	return newFunction
 }
+
+/**
+ * Split a string into grapheme clusters. This is an approximation of the Unicode segmentation rules
+ * that keeps combining marks, variation selectors, zero width joiner sequences and flag pairs together.
+ * @param str : string - The string to split.
+ * @return []string - The grapheme clusters.
+ */
+func SplitGraphemes(str string) []string {
+	clusters := []string{}
+	cluster := []rune{}
+	joinNext := false // Previous rune was a zero width joiner
+	regionalCount := 0
+
+	for _, r := range str {
+		extends := unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) || (r >= 0xFE00 && r <= 0xFE0F) || (r >= 0x1F3FB && r <= 0x1F3FF)
+		isRegional := r >= 0x1F1E6 && r <= 0x1F1FF
+
+		if len(cluster) > 0 && !extends && !joinNext && r != 0x200D && !(isRegional && regionalCount%2 == 1) && !(r == '\n' && cluster[len(cluster)-1] == '\r') {
+			clusters = append(clusters, string(cluster))
+			cluster = []rune{}
+			regionalCount = 0
+		}
+
+		if isRegional {
+			regionalCount++
+		}
+		joinNext = r == 0x200D
+		cluster = append(cluster, r)
+	}
+
+	if len(cluster) > 0 {
+		clusters = append(clusters, string(cluster))
+	}
+
+	return clusters
+}
+
+/**
+ * Copy the value of a variable. Arrays and objects are reference types in Kode, so a copy is the only way to
+ * get independent storage. A shallow copy duplicates the top-level array or object fields, while a deep copy
+ * duplicates every nested array and object as well. Copies are never constant nor frozen at the copied levels.
+ * @param variable : Variable - The variable to copy.
+ * @param deep : bool - True to copy nested values recursively.
+ * @return Variable - The copied variable.
+ */
+func CopyValue(variable Variable, deep bool) Variable {
+	switch value := variable.Value.(type) {
+	case *Array:
+		array := make([]Variable, len(value.Elements))
+		for i, element := range value.Elements {
+			if deep {
+				array[i] = CopyValue(element, true)
+			} else {
+				array[i] = element
+			}
+		}
+		variable.Value = &Array{Elements: array}
+	case Function:
+		variable.Value = CopyInstance(value, deep)
+	}
+	variable.Constant = false
+	variable.Frozen = false
+	return variable
+}
+
+/**
+ * Copy a function value along with its fields. If the function is an object (e.g. returned by "self"),
+ * its methods are bound to the copy so they update the copied fields instead of the original ones.
+ * @param instance : Function - The function value to copy.
+ * @param deep : bool - True to copy the fields recursively.
+ * @return Function - The copied function value.
+ */
+func CopyInstance(instance Function, deep bool) Function {
+	newScope := CopyFunction(&instance)
+
+	// An object shares its variables with the scope that created it
+	if IsInstance(instance) {
+		(*newScope).Parent = newScope
+	}
+
+	for key, value := range instance.Variables {
+
+		// Interpreter settings stay shared
+		if value == nil || instance.isSystemVariable(key) {
+			continue
+		}
+
+		field := *value
+		field.Frozen = false
+		if function, ok := field.Value.(Function); ok && sameVariableMap(function.Variables, instance.Variables) {
+			// Reference to the object itself (e.g. "val me = self")
+			field.Value = *newScope
+		} else if deep {
+			field = CopyValue(field, true)
+		}
+
+		// Bind the methods to the copy
+		if function, ok := field.Value.(Function); ok && function.Parent != nil && sameVariableMap(function.Parent.Variables, instance.Variables) {
+			function.Parent = newScope
+			field.Value = function
+		}
+
+		(*newScope).Variables[key] = &field
+	}
+
+	return *newScope
+}
+
+/**
+ * Check if two variable maps are the same map (not only equal).
+ * @return bool - True if both maps share the same storage.
+ */
+func sameVariableMap(a map[string]*Variable, b map[string]*Variable) bool {
+	return a != nil && b != nil && variableMapKey(a) == variableMapKey(b)
+}
+
+/**
+ * Get a key identifying a variable map (e.g. to detect cycles).
+ * @return uintptr - The address of the map.
+ */
+func variableMapKey(variables map[string]*Variable) uintptr {
+	return reflect.ValueOf(variables).Pointer()
+}
+
+/**
+ * Create an object (the equivalent of a function returning "self") holding fields.
+ * @param name : string - The name of the object.
+ * @param fields : map[string]*Variable - The fields of the object.
+ * @return Variable - The object.
+ */
+func CreateObject(name string, fields map[string]*Variable) Variable {
+	return CreateOrderedObject(name, nil, fields)
+}
+
+/**
+ * Create an object whose fields are listed in a given order (e.g. the columns of a CSV record) instead of by name.
+ * @param name : string - The name of the object.
+ * @param order : []string - The names of the fields in order, or nil to sort them by name.
+ * @param fields : map[string]*Variable - The fields of the object.
+ * @return Variable - The object.
+ */
+func CreateOrderedObject(name string, order []string, fields map[string]*Variable) Variable {
+	object := &Function{
+		Arguments: []Argument{},
+		Variables: fields,
+		Return:    "null",
+		Name:      name,
+		Fields:    order,
+	}
+	object.Parent = object
+	return CreateVariable(*object)
+}
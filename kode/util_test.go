@@ -0,0 +1,191 @@
+package kode
+
+import (
+	"bytes"
+	"testing"
+)
+
+/**
+ * Run a program and get what it prints.
+ * @param t : *testing.T - The test.
+ * @param code : string - The program.
+ * @return string - The output of the program.
+ */
+func runOutput(t *testing.T, code string) string {
+	t.Helper()
+	interpreter := NewInterpreter()
+	output := bytes.Buffer{}
+	interpreter.Output = &output
+	if err := interpreter.Run(code); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return output.String()
+}
+
+func TestCopyArrays(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{
+			name: "shallow copy shares nested arrays",
+			code: `int[][] a = [[1, 2], [3]]
+val b = copy(a)
+b[0][0] = 9
+push(b, [4])
+print(a, b)`,
+			expected: "[[9, 2], [3]] [[9, 2], [3], [4]]\n",
+		},
+		{
+			name: "deep copy shares nothing",
+			code: `int[][] a = [[1, 2], [3]]
+val b = deepCopy(a)
+b[0][0] = 9
+push(b[1], 4)
+print(a, b)`,
+			expected: "[[1, 2], [3]] [[9, 2], [3, 4]]\n",
+		},
+		{
+			name: "assignment aliases after push and insert",
+			code: `int[] a = [1]
+val b = a
+push(a, 2)
+insert(b, 0, 0)
+print(a, b)`,
+			expected: "[0, 1, 2] [0, 1, 2]\n",
+		},
+		{
+			name: "copy is independent after push and insert",
+			code: `int[] a = [1]
+val b = copy(a)
+push(a, 2)
+insert(b, 0, 0)
+print(a, b)`,
+			expected: "[1, 2] [0, 1]\n",
+		},
+		{
+			name: "arguments share arrays",
+			code: `func grow(int[] values)
+  push(values, 3)
+end grow
+int[] a = [1, 2]
+grow(a)
+grow(copy(a))
+print(a)`,
+			expected: "[1, 2, 3]\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if output := runOutput(t, test.code); output != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, output)
+			}
+		})
+	}
+}
+
+func TestCopyObjects(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{
+			name: "shallow copy shares nested values",
+			code: `func Box() func
+  int[] items = [1]
+  int count = 1
+  func setCount(int value)
+    count = value
+  end setCount
+  return self
+end Box
+val a = new Box()
+val b = copy(a)
+b.setCount(2)
+push(b.items, 2)
+print(a.count, a.items, b.count, b.items)`,
+			expected: "1 [1, 2] 2 [1, 2]\n",
+		},
+		{
+			name: "deep copy shares nothing",
+			code: `func Box() func
+  int[] items = [1]
+  return self
+end Box
+val a = new Box()
+val b = deepCopy(a)
+push(b.items, 2)
+print(a.items, b.items)`,
+			expected: "[1] [1, 2]\n",
+		},
+		{
+			name: "methods are bound to the copy",
+			code: `func Counter() func
+  int count = 0
+  func increment()
+    count = count + 1
+  end increment
+  return self
+end Counter
+val a = new Counter()
+val b = copy(a)
+b.increment()
+b.increment()
+a.increment()
+print(a.count, b.count)`,
+			expected: "1 2\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if output := runOutput(t, test.code); output != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, output)
+			}
+		})
+	}
+}
+
+func TestCopySelfReference(t *testing.T) {
+	me := Variable{}
+	fields := map[string]*Variable{"value": {Value: int64(1), Type: "int"}, "me": &me}
+	object := CreateObject("node", fields)
+	me = object
+
+	for _, deep := range []bool{false, true} {
+		copied := CopyValue(object, deep).Value.(Function)
+		if sameVariableMap(copied.Variables, fields) {
+			t.Fatalf("deep=%v: the copy shares the fields of the original", deep)
+		}
+
+		// The reference to the object itself points to the copy, not to the original
+		self := (*copied.Variables["me"]).Value.(Function)
+		if !sameVariableMap(self.Variables, copied.Variables) {
+			t.Errorf("deep=%v: the copy does not refer to itself", deep)
+		}
+
+		*copied.Variables["value"] = CreateVariable(int64(2))
+		if (*fields["value"]).Value.(int64) != 1 {
+			t.Errorf("deep=%v: modifying the copy changed the original", deep)
+		}
+	}
+}
+
+func TestSystemVariableNamesInData(t *testing.T) {
+	code := `val o = parseJSON("{\"args\": [1], \"_DEBUG\": true}")
+print(toJSON(o))
+val c = deepCopy(o)
+push(c.args, 2)
+print(o, c)
+func Box() func
+  return self
+end Box
+print(new Box())`
+	expected := "{\"_DEBUG\":true,\"args\":[1]}\nobject{_DEBUG: true, args: [1]} object{_DEBUG: true, args: [1, 2]}\nBox{}\n"
+	if output := runOutput(t, code); output != expected {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+}
@@ -2,14 +2,21 @@ package kode
 
 import (
 	"regexp"
+	"time"
 )
 
+// ! Variable : A value and its type.
+// Primitives (int, float, string, bool) are values and are copied on assignment and when passed to functions.
+// Arrays and objects (func) are references: assignments, arguments and returns share the same storage.
+// Use "copy()" or "deepCopy()" to get independent storage.
 type Variable struct {
-	Value interface{}
-	Type  string
+	Value    interface{}
+	Type     string
+	Constant bool // The variable cannot be reassigned (e.g. "const" declarations and "readonly" parameters)
+	Frozen   bool // The variable is a field of a frozen object and cannot be reassigned, even by its methods
 }
 
-var varFormat, _ = regexp.Compile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+var varFormat, _ = regexp.Compile(`^[\p{L}_][\p{L}\p{N}_]*$`)
 
 /**
  * Create a new variable.
@@ -17,6 +24,12 @@ var varFormat, _ = regexp.Compile("^[a-zA-Z_][a-zA-Z0-9_]*$")
  * @return Variable - The new variable.
  */
 func CreateVariable(value interface{}) Variable {
+
+	// Arrays are stored by reference
+	if elements, ok := value.([]Variable); ok {
+		value = &Array{Elements: elements}
+	}
+
 	return Variable{
 		Value: value,
 		Type:  EvaluateType(value),
@@ -91,8 +104,19 @@ func IsReservedWord(name string) bool {
 		return true
 	case "new":
 		return true
+	case "const":
+		return true
+	case "readonly":
+		return true
+	case "let":
+		return true
+	case "try":
+		return true
+	case "catch":
+		return true
 	default:
-		return ExistsBuiltIn(name)
+		_, isConstant := BuiltInConstant(name)
+		return isConstant || ExistsBuiltIn(name)
 	}
 }
 
@@ -115,8 +139,14 @@ func EvaluateType(value interface{}) string {
 		return "func"
 	case int:
 		return "illegal_int"
-	case []Variable:
-		return EvaluateArrayType(value.([]Variable)) // i.e. val[], int[], float[], string[], bool[], func[]
+	case *Array:
+		return EvaluateArrayType(value.(*Array).Elements) // i.e. val[], int[], float[], string[], bool[], func[]
+	case *regexp.Regexp:
+		return "regex"
+	case *CSVReader:
+		return "csv"
+	case time.Time:
+		return "datetime"
 	default:
 		return "null"
 	}
@@ -154,3 +184,81 @@ func NullVariable() *Variable {
 		Type:  "null",
 	}
 }
+
+/**
+ * Create a deep-frozen copy of a variable for a constant or a read-only parameter. Nested arrays and objects are
+ * copied and frozen as well.
+ * @param variable : Variable - The variable to freeze.
+ * @return Variable - The frozen copy.
+ */
+func FreezeVariable(variable Variable) Variable {
+	switch value := variable.Value.(type) {
+	case *Array:
+		frozenArray := make([]Variable, len(value.Elements))
+		for i, element := range value.Elements {
+			frozenArray[i] = FreezeVariable(element)
+		}
+		variable.Value = &Array{Elements: frozenArray, Frozen: true, Constant: true}
+	case Function:
+		if IsInstance(value) {
+			object := CopyInstance(value, false)
+			freezeFields(object, func(field *Variable) {
+				*field = FreezeVariable(*field)
+			})
+			variable.Value = object
+		}
+	}
+	return variable
+}
+
+/**
+ * Deep-freeze the storage of a variable, so the change is seen by every variable holding the same arrays and
+ * objects.
+ * @param variable : Variable - The variable to freeze.
+ */
+func FreezeStorage(variable Variable) {
+	freezeStorage(variable, map[uintptr]bool{})
+}
+
+/**
+ * Deep-freeze the storage of a variable, skipping the objects already frozen.
+ * @param variable : Variable - The variable to freeze.
+ * @param frozen : map[uintptr]bool - The objects already frozen (by the address of their fields).
+ */
+func freezeStorage(variable Variable, frozen map[uintptr]bool) {
+	switch value := variable.Value.(type) {
+	case *Array:
+		if !value.Frozen {
+			value.Frozen = true
+			for _, element := range value.Elements {
+				freezeStorage(element, frozen)
+			}
+		}
+	case Function:
+		if IsInstance(value) && !frozen[variableMapKey(value.Variables)] {
+			frozen[variableMapKey(value.Variables)] = true
+			freezeFields(value, func(field *Variable) {
+				freezeStorage(*field, frozen)
+			})
+		}
+	}
+}
+
+/**
+ * Freeze the fields of an object so they cannot be reassigned, then freeze their values.
+ * @param object : Function - The object.
+ * @param freeze : func(*Variable) - The function freezing the value of a field.
+ */
+func freezeFields(object Function, freeze func(field *Variable)) {
+	for name, field := range object.Variables {
+		if field == nil || object.isSystemVariable(name) {
+			continue
+		}
+
+		// A reference to the object itself (e.g. "val me = self") is already being frozen
+		if instance, ok := field.Value.(Function); !ok || !sameVariableMap(instance.Variables, object.Variables) {
+			freeze(field)
+		}
+		field.Frozen = true
+	}
+}
@@ -0,0 +1,56 @@
+package kode
+
+import "testing"
+
+const boxCode = `func Box() func
+  int[] items = [1]
+  int count = 1
+  func add(int value)
+    push(items, value)
+  end add
+  func bump()
+    count = count + 1
+  end bump
+  return self
+end Box
+`
+
+func TestFrozenObjects(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+	}{
+		{name: "freeze arrays of fields", code: "val b = freeze(new Box())\npush(b.items, 2)"},
+		{name: "freeze fields changed by methods", code: "val b = freeze(new Box())\nb.bump()"},
+		{name: "freeze arrays changed by methods", code: "val b = freeze(new Box())\nb.add(2)"},
+		{name: "constant fields", code: "const b = new Box()\nb.bump()"},
+		{name: "constant arrays of fields", code: "const b = new Box()\nb.add(3)"},
+		{name: "nested objects", code: "val o = freeze(parseJSON(\"{\\\"inner\\\": {\\\"values\\\": [1]}}\"))\npush(o.inner.values, 2)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if code := runError(t, boxCode+test.code).ErrorCode(); code != E_READ_ONLY {
+				t.Errorf("expected %s, got %s", E_READ_ONLY, code)
+			}
+		})
+	}
+}
+
+func TestFrozenObjectCopies(t *testing.T) {
+	// Copies can be modified, and a constant does not freeze the object it was declared from
+	code := boxCode + `val a = freeze(new Box())
+val b = copy(a)
+b.bump()
+val c = deepCopy(a)
+c.add(2)
+val d = new Box()
+const e = d
+d.bump()
+d.add(3)
+print(a.count, a.items, b.count, c.items, d.count, d.items, e.count, e.items)`
+	expected := "1 [1] 2 [1, 2] 2 [1, 3] 1 [1]\n"
+	if output := runOutput(t, code); output != expected {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+}
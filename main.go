@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -15,22 +16,115 @@ const (
 	VERSION = "alpha 0.5.0"
 )
 
+// Exit statuses of the interpreter (a program can also choose its own with "exit")
+const (
+	EXIT_SUCCESS       = 0
+	EXIT_RUNTIME_ERROR = 1
+	EXIT_SYNTAX_ERROR  = 2
+	EXIT_IO_ERROR      = 3
+	EXIT_USAGE_ERROR   = 64
+)
+
 func main() {
 
-	path := flag.String("run", "main.kd", "Path to the Kode file.")
-	showVersion := flag.Bool("version", false, "Show the current version of Kode.")
-	StdIn := flag.Bool("runStdIn", false, "Read from stdin.")
-	flag.Parse()
+	// Without a command, the flags and arguments are the ones of "kode run"
+	opts := newOptions()
+	flags := newFlagSet("kode", "[command] [flags] [file.kd] [args...]", "Run a Kode program, or one of the commands below.", opts)
+	addInterpreterFlags(flags, opts)
+	run := addRunFlags(flags)
+	flags.Usage = mainUsage(flags)
+
+	if status, ok := parseFlags(flags, os.Args[1:]); !ok {
+		os.Exit(status)
+	}
+
+	if (*run).version {
+		os.Exit(versionCommand(nil, opts))
+	}
+
+	if command, found := findCommand(flags.Arg(0)); found && !isFlagSet(flags, "run") && !isFlagSet(flags, "e") {
+		os.Exit(command.run(flags.Args()[1:], opts))
+	}
+
+	os.Exit(run.execute(flags, opts))
+}
+
+// ! runOptions : The flags of "kode run".
+// -------------------------
+// ! path : The path of the file to run (-run).
+// -------------------------
+// ! stdin : True to read the program from the standard input until "exit" (-runStdIn).
+// -------------------------
+// ! inline : The code to run (-e).
+// -------------------------
+// ! version : True to show the version (-version).
+type runOptions struct {
+	path    string
+	stdin   bool
+	inline  string
+	version bool
+}
+
+/**
+ * Add the flags of "kode run" to a flag set.
+ * @param flags : *flag.FlagSet - The flag set.
+ * @return *runOptions - The options set by the flags.
+ */
+func addRunFlags(flags *flag.FlagSet) *runOptions {
+	run := &runOptions{}
+	flags.StringVar(&(*run).path, "run", "main.kd", "Path to the Kode file (it can also be given as the first argument).")
+	flags.BoolVar(&(*run).version, "version", false, "Show the current version of Kode.")
+	flags.BoolVar(&(*run).stdin, "runStdIn", false, "Read from stdin.")
+	flags.StringVar(&(*run).inline, "e", "", "Run Kode code and print the value of its last expression, e.g. -e 'print(1 + 2)'.")
+	return run
+}
+
+/**
+ * Run "kode run": run a Kode program.
+ * @param args : []string - The arguments after the command.
+ * @param opts : *options - The global options.
+ * @return int - The exit status.
+ */
+func runCommand(args []string, opts *options) int {
+	flags := newFlagSet("run", "[flags] [file.kd] [args...]", "Run a Kode program. The program is read from the standard input if it is piped and no file is given.", opts)
+	addInterpreterFlags(flags, opts)
+	run := addRunFlags(flags)
+
+	if status, ok := parseFlags(flags, args); !ok {
+		return status
+	}
+
+	if (*run).version {
+		return versionCommand(nil, opts)
+	}
+	return run.execute(flags, opts)
+}
+
+/**
+ * Run the program given by the flags.
+ * @param flags : *flag.FlagSet - The parsed flags. The arguments after the flags (or after "--") are passed to the program.
+ * @param opts : *options - The global options.
+ * @return int - The exit status.
+ */
+func (run *runOptions) execute(flags *flag.FlagSet, opts *options) int {
+	interpreter := opts.newInterpreter()
+	interpreter.Args = flags.Args()
+
+	if isFlagSet(flags, "e") {
+		return eval(interpreter, (*run).inline, opts)
+	}
 
-	if *StdIn {
-		in := bufio.NewScanner(os.Stdin)
+	if (*run).stdin {
+		// The lines after "exit" are left to the program input
+		in := bufio.NewReader(os.Stdin)
+		interpreter.SetInput(in)
 
 		code := ""
 
-		for in.Scan() {
+		for {
 
-			bytes := in.Bytes()
-			txt := string(bytes)
+			txt, err := in.ReadString('\n')
+			txt = strings.TrimRight(txt, "\r\n")
 
 			if strings.ReplaceAll(txt, " ", "") == "exit" {
 				break
@@ -40,34 +134,104 @@ func main() {
 				code += txt + "\n"
 			}
 
+			if err != nil {
+				break
+			}
+
 		}
 
-		err := kode.Run(code)
+		return execute(interpreter, code, opts)
+	}
+
+	// The file can be given as the first argument: kode file.kd [args...]
+	path := (*run).path
+	if !isFlagSet(flags, "run") && flags.NArg() > 0 {
+		path = flags.Arg(0)
+		interpreter.Args = flags.Args()[1:]
 
+		// The arguments of the program can be separated from the file by "--" (e.g. kode main.kd -- a b)
+		if len(interpreter.Args) > 0 && interpreter.Args[0] == "--" {
+			interpreter.Args = interpreter.Args[1:]
+		}
+	} else if !isFlagSet(flags, "run") && isPiped(os.Stdin) {
+		// Piped code runs until the end of the input (e.g. echo 'print(1)' | kode)
+		code, err := ioutil.ReadAll(os.Stdin)
 		if err != nil {
-			fmt.Println(err.Error())
+			opts.printError(kode.CreateError(kode.ErrorMessage(kode.MSG_READ_STANDARD_IN), 0))
+			return EXIT_IO_ERROR
 		}
-
-		os.Exit(1)
+		return execute(interpreter, string(code), opts)
 	}
 
-	if *showVersion {
-		fmt.Println("Kode version: " + VERSION)
-		fmt.Println("Created by Eduard A. and Frédéric J.")
-		fmt.Println("Make sure to check out the GitHub repository: https://github.com/PersoSirEduard/Kode")
-		return
+	code, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		opts.printError(kode.CreateError(kode.ErrorMessage(kode.MSG_READ_FILE, path), 0))
+		return EXIT_IO_ERROR
 	}
 
-	code, err := ioutil.ReadFile(*path)
+	interpreter.File = path
+	return execute(interpreter, string(code), opts)
+}
 
-	if err != nil {
-		println("Error: Could not find and read the file \"" + *path + "\".")
+/**
+ * Run a program and report its error.
+ * @param interpreter : *kode.Interpreter - The interpreter.
+ * @param code : string - The code of the program.
+ * @param opts : *options - The global options.
+ * @return int - The exit status of the program.
+ */
+func execute(interpreter *kode.Interpreter, code string, opts *options) int {
+	return report(interpreter.Run(code), opts)
+}
+
+/**
+ * Run a program and print the value of its last line if it is an expression.
+ * @param interpreter : *kode.Interpreter - The interpreter.
+ * @param code : string - The code of the program.
+ * @param opts : *options - The global options.
+ * @return int - The exit status of the program.
+ */
+func eval(interpreter *kode.Interpreter, code string, opts *options) int {
+	value, err := interpreter.Eval(code)
+	if err == nil && value != nil && value.Type != "null" {
+		fmt.Println(kode.FormatVariable(*value))
 	}
+	return report(err, opts)
+}
+
+/**
+ * Check if a file is a pipe or a regular file rather than a terminal.
+ * @param file : *os.File - The file (e.g. the standard input).
+ * @return bool - True if the file is not a terminal.
+ */
+func isPiped(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
 
-	err = kode.Run(string(code))
+/**
+ * Report the error of a program.
+ * @param err : error - The error returned by the interpreter, or nil.
+ * @param opts : *options - The global options.
+ * @return int - The exit status of the program.
+ */
+func report(err error, opts *options) int {
+	if err == nil {
+		return EXIT_SUCCESS
+	}
 
-	if err != nil {
-		println(err.Error())
+	// The program chose its exit code
+	var exitError *kode.ExitError
+	if errors.As(err, &exitError) {
+		return exitError.Code
 	}
 
+	opts.printError(err)
+
+	var errorStack *kode.ErrorStack
+	if errors.As(err, &errorStack) && errorStack.Cause().Kind == kode.SYNTAX_ERROR {
+		return EXIT_SYNTAX_ERROR
+	}
+	return EXIT_RUNTIME_ERROR
 }